@@ -4,13 +4,22 @@ go 1.14
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/alicebob/miniredis/v2 v2.14.1
+	github.com/gin-gonic/gin v1.6.3
 	github.com/go-playground/validator/v10 v10.4.1
-	github.com/go-redis/redis v6.15.9+incompatible
-	github.com/go-redsync/redsync/v3 v3.1.0
+	github.com/go-redis/redis/v8 v8.4.4
+	github.com/go-redsync/redsync/v4 v4.0.4
+	github.com/go-sql-driver/mysql v1.5.0
+	github.com/golang/snappy v0.0.2
 	github.com/jinzhu/now v1.1.1
+	github.com/labstack/echo/v4 v4.1.16
 	github.com/liip/sheriff v0.9.0
 	github.com/stretchr/testify v1.6.1
-	golang.org/x/text v0.3.2
+	github.com/vmihailenco/msgpack/v5 v5.1.0
+	golang.org/x/sync v0.0.0-20201020160332-67f06af15bc9
+	golang.org/x/text v0.3.3
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc v1.34.0
 	gorm.io/driver/mysql v1.0.3
 	gorm.io/gorm v1.20.8
 )
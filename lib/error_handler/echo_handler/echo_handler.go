@@ -0,0 +1,52 @@
+package echo_handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/PhantomX7/go-core/lib/error_handler"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// ErrorHandler will return echo.HTTPErrorHandler that write the error with the handler
+// *echo.HTTPError such as unknown route is converted into CustomError with the same http code
+// e.g. e.HTTPErrorHandler = echo_handler.ErrorHandler(handler)
+func ErrorHandler(handler error_handler.Handler) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		var httpError *echo.HTTPError
+		if errors.As(err, &httpError) {
+			err = errors.New(statusCode(httpError.Code), httpError.Code, httpError.Message).WithCause(err)
+		}
+
+		handler.WriteError(c.Response(), c.Request(), err)
+	}
+}
+
+// Recover will return echo middleware that recover panic into error handled by ErrorHandler
+func Recover(handler error_handler.Handler) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if recovered == http.ErrAbortHandler {
+						panic(recovered)
+					}
+					err = handler.Recover(recovered)
+				}
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// statusCode build error code from the http status, e.g. 405 become METHOD_NOT_ALLOWED
+func statusCode(httpCode int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(httpCode), " ", "_"))
+}
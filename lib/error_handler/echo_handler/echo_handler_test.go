@@ -0,0 +1,47 @@
+package echo_handler_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/error_handler"
+	"github.com/PhantomX7/go-core/lib/error_handler/echo_handler"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestErrorHandler(t *testing.T) {
+	handler := error_handler.New(error_handler.Config{
+		Logger: log.New(&bytes.Buffer{}, "", 0),
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = echo_handler.ErrorHandler(handler)
+	e.Use(echo_handler.Recover(handler))
+	e.GET("/orders/:id", func(c echo.Context) error {
+		return errors.ErrNotFound
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("nil map")
+	})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_code":"NOT_FOUND"`)
+
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_code":"INTERNAL_ERROR"`)
+
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/1", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_code":"METHOD_NOT_ALLOWED"`)
+}
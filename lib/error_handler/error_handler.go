@@ -0,0 +1,122 @@
+package error_handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/response_util"
+	"github.com/PhantomX7/go-core/utility/validators"
+)
+
+// HandlerFunc is http handler that return the error instead of writing it
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Logger is used to log internal error and recovered panic, *log.Logger satisfy it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WriterFunc will write the error response, e.g. response_util.WriteError or response_util.WriteProblem
+type WriterFunc func(w http.ResponseWriter, r *http.Request, err error) error
+
+type Config struct {
+	// AppEnv other than development will hide the message of 5xx error from the response
+	AppEnv string
+	// Logger default to standard logger writing to stderr
+	Logger Logger
+	// Writer default to response_util.WriteError
+	Writer WriterFunc
+}
+
+type Handler interface {
+	Handle(fn HandlerFunc) http.Handler
+	Middleware(next http.Handler) http.Handler
+	WriteError(w http.ResponseWriter, r *http.Request, err error)
+	Recover(recovered interface{}) error
+}
+
+type ErrorHandler struct {
+	config Config
+}
+
+func New(config Config) Handler {
+	if config.Logger == nil {
+		config.Logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	if config.Writer == nil {
+		config.Writer = response_util.WriteError
+	}
+
+	return &ErrorHandler{
+		config: config,
+	}
+}
+
+// Handle will convert fn into http.Handler that write the returned error and recover panic
+func (h *ErrorHandler) Handle(fn HandlerFunc) http.Handler {
+	return h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			h.WriteError(w, r, err)
+		}
+	}))
+}
+
+// Middleware will recover panic of the next handler into errors.ErrTetapTenangTetapSemangat
+func (h *ErrorHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				// http.ErrAbortHandler is used to abort the response on purpose
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				h.WriteError(w, r, h.Recover(recovered))
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteError will write err with its http code
+// validator.ValidationErrors is converted by validators.ValidationError,
+// error that is not errors.CustomError is written as errors.ErrTetapTenangTetapSemangat
+// 5xx error and error without valid http code are written as 500, logged with its stack trace
+// and its message is hidden outside development
+func (h *ErrorHandler) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	err = validators.ValidationError(err)
+
+	var customError errors.CustomError
+	if !errors.As(err, &customError) {
+		customError = errors.ErrTetapTenangTetapSemangat.WithCause(err)
+	}
+
+	if customError.HTTPCode < 100 || customError.HTTPCode >= http.StatusInternalServerError {
+		if customError.HTTPCode < 100 || customError.HTTPCode > 599 {
+			customError.HTTPCode = http.StatusInternalServerError
+		}
+		h.config.Logger.Printf("%s %s: %+v", r.Method, r.URL.RequestURI(), customError)
+		if h.config.AppEnv != "development" {
+			customError = customError.WithMessage(errors.ErrTetapTenangTetapSemangat.Message)
+		}
+	}
+
+	if err := h.config.Writer(w, r, customError); err != nil {
+		h.config.Logger.Printf("%s %s: write error response: %v", r.Method, r.URL.RequestURI(), err)
+	}
+}
+
+// Recover will convert recovered panic value into errors.ErrTetapTenangTetapSemangat with stack trace
+func (h *ErrorHandler) Recover(recovered interface{}) error {
+	cause, ok := recovered.(error)
+	if !ok {
+		cause = fmt.Errorf("%v", recovered)
+	}
+
+	return errors.ErrTetapTenangTetapSemangat.
+		WithCause(fmt.Errorf("panic: %w", cause)).
+		WithStack()
+}
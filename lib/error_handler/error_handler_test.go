@@ -0,0 +1,94 @@
+package error_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/error_handler"
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+func serve(handler http.Handler) (*httptest.ResponseRecorder, response_util.ErrorResponse) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	var body response_util.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	return w, body
+}
+
+func TestHandle(t *testing.T) {
+	var logs bytes.Buffer
+	handler := error_handler.New(error_handler.Config{
+		AppEnv: "production",
+		Logger: log.New(&logs, "", 0),
+	})
+
+	t.Run("with custom error", func(t *testing.T) {
+		w, body := serve(handler.Handle(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.Wrap(errors.ErrNotFound, nil, "order 1")
+		}))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "Record not exist: order 1", body.Error.Message)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("with internal error", func(t *testing.T) {
+		w, body := serve(handler.Handle(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.ErrServiceUnavailable.WithMessage("redis connection refused")
+		}))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, errors.ErrTetapTenangTetapSemangat.Message, body.Error.Message)
+		assert.Contains(t, logs.String(), "GET /orders/1: redis connection refused")
+		logs.Reset()
+	})
+
+	t.Run("with zero http code", func(t *testing.T) {
+		w, body := serve(handler.Handle(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("LEGACY_ERROR", 0, "table orders is locked")
+		}))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, errors.ErrTetapTenangTetapSemangat.Message, body.Error.Message)
+		assert.Contains(t, logs.String(), "GET /orders/1: table orders is locked")
+		logs.Reset()
+	})
+
+	t.Run("with panic", func(t *testing.T) {
+		w, body := serve(handler.Handle(func(w http.ResponseWriter, r *http.Request) error {
+			panic("nil map")
+		}))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "INTERNAL_ERROR", body.Error.Code)
+		assert.Contains(t, logs.String(), "panic: nil map")
+		assert.Contains(t, logs.String(), "origin: ")
+		logs.Reset()
+	})
+}
+
+func TestHandleInDevelopment(t *testing.T) {
+	handler := error_handler.New(error_handler.Config{
+		AppEnv: "development",
+		Logger: log.New(&bytes.Buffer{}, "", 0),
+		Writer: response_util.WriteProblem,
+	})
+
+	w := httptest.NewRecorder()
+	handler.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.ErrServiceUnavailable.WithMessage("redis connection refused")
+	}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, response_util.ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "redis connection refused")
+}
@@ -0,0 +1,42 @@
+package gin_handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/PhantomX7/go-core/lib/error_handler"
+)
+
+// New will return gin middleware that recover panic and write the last error of the context
+// the handler should add the error with c.Error(err) and return without writing the response
+func New(handler error_handler.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				handler.WriteError(c.Writer, c.Request, handler.Recover(recovered))
+				c.Abort()
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			handler.WriteError(c.Writer, c.Request, c.Errors.Last().Err)
+		}
+	}
+}
+
+// Handle will convert handler that return error into gin.HandlerFunc
+// the returned error is added to the context and written by the middleware
+func Handle(fn func(c *gin.Context) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := fn(c); err != nil {
+			_ = c.Error(err)
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package gin_handler_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/error_handler"
+	"github.com/PhantomX7/go-core/lib/error_handler/gin_handler"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestNew(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(gin_handler.New(error_handler.New(error_handler.Config{
+		Logger: log.New(&bytes.Buffer{}, "", 0),
+	})))
+	router.GET("/orders/:id", gin_handler.Handle(func(c *gin.Context) error {
+		return errors.ErrNotFound
+	}))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("nil map")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_code":"NOT_FOUND"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_code":"INTERNAL_ERROR"`)
+}
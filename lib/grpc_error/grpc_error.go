@@ -0,0 +1,275 @@
+package grpc_error
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// ErrorDomain is the domain of errdetails.ErrorInfo attached to the status, set it once on application start
+var ErrorDomain = "go-core"
+
+// metadata key of errdetails.ErrorInfo holding the http code and the code the error is derived from
+const (
+	httpCodeMetadataKey = "http_code"
+	kindMetadataKey     = "error_kind"
+)
+
+var httpCodes = map[int]codes.Code{
+	http.StatusOK:                    codes.OK,
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	errors.StatusClientClosedRequest: codes.Canceled,
+	http.StatusInternalServerError:   codes.Internal,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+}
+
+var grpcCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           errors.StatusClientClosedRequest,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// Code will return the grpc code of the http code
+// unknown 4xx become FailedPrecondition and other become Unknown
+func Code(httpCode int) codes.Code {
+	if code, ok := httpCodes[httpCode]; ok {
+		return code
+	}
+	if httpCode >= http.StatusBadRequest && httpCode < http.StatusInternalServerError {
+		return codes.FailedPrecondition
+	}
+	return codes.Unknown
+}
+
+// HTTPCode will return the http code of the grpc code
+func HTTPCode(code codes.Code) int {
+	if httpCode, ok := grpcCodes[code]; ok {
+		return httpCode
+	}
+	return http.StatusInternalServerError
+}
+
+// ToStatus will convert err into grpc status
+// CustomError code, http code and details are kept in errdetails.ErrorInfo,
+// field error message such as validation error is kept in errdetails.BadRequest
+// grpc status error is returned as is and other error become errors.ErrTetapTenangTetapSemangat
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var customError errors.CustomError
+	if !errors.As(err, &customError) {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+
+		switch {
+		case errors.Is(err, context.Canceled):
+			customError = errors.ErrClientClosedRequest
+		case errors.Is(err, context.DeadlineExceeded):
+			customError = errors.ErrGatewayTimeout
+		default:
+			customError = errors.ErrTetapTenangTetapSemangat
+		}
+	}
+
+	message, fieldMessages := statusMessage(customError)
+	st := status.New(Code(customError.HTTPCode), message)
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: customError.Code,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			httpCodeMetadataKey: strconv.Itoa(customError.HTTPCode),
+		},
+	}
+	for key, value := range customError.Details() {
+		if encoded, err := json.Marshal(value); err == nil {
+			errorInfo.Metadata[key] = string(encoded)
+		}
+	}
+	if kind := customError.Kind(); kind != customError.Code {
+		errorInfo.Metadata[kindMetadataKey] = kind
+	}
+
+	var withDetails *status.Status
+	if len(fieldMessages) > 0 {
+		withDetails, err = st.WithDetails(errorInfo, badRequest(fieldMessages))
+	} else {
+		withDetails, err = st.WithDetails(errorInfo)
+	}
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// ToError will convert err into grpc status error, nil is returned as is
+func ToError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return ToStatus(err).Err()
+}
+
+// FromStatus will convert grpc status into CustomError
+// http code, code and details are restored from errdetails.ErrorInfo when it exist,
+// otherwise the http code is mapped from the grpc code.
+// error of registered code is rebuilt from its definition, so errors.Is(err, errors.ErrNotFound) still match
+func FromStatus(st *status.Status) errors.CustomError {
+	httpCode := HTTPCode(st.Code())
+	customError := errors.New("", httpCode, st.Message()).WithCause(st.Err())
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			customError = registeredError(detail.GetReason(), detail.GetMetadata()[kindMetadataKey], httpCode, st.Message()).
+				WithCause(st.Err())
+			restoreErrorInfo(&customError, detail.GetMetadata())
+		case *errdetails.BadRequest:
+			fieldMessages := make(map[string]string, len(detail.GetFieldViolations()))
+			for _, violation := range detail.GetFieldViolations() {
+				fieldMessages[violation.GetField()] = violation.GetDescription()
+			}
+			customError.Message = fieldMessages
+		}
+	}
+
+	return customError
+}
+
+// FromError will convert grpc status error into CustomError
+// nil and error that is not grpc status error is returned as is
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return FromStatus(st)
+}
+
+// UnaryServerInterceptor will convert error returned by unary handler into grpc status error
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, ToError(err)
+	}
+}
+
+// StreamServerInterceptor will convert error returned by stream handler into grpc status error
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return ToError(handler(srv, ss))
+	}
+}
+
+// statusMessage will return the status message and the field messages of non string message
+func statusMessage(customError errors.CustomError) (string, map[string]string) {
+	switch message := customError.Message.(type) {
+	case string:
+		return message, nil
+	case map[string]string:
+		return http.StatusText(customError.HTTPCode), message
+	default:
+		return fmt.Sprint(customError.Message), nil
+	}
+}
+
+// badRequest will convert field messages into errdetails.BadRequest sorted by field
+func badRequest(fieldMessages map[string]string) *errdetails.BadRequest {
+	fields := make([]string, 0, len(fieldMessages))
+	for field := range fieldMessages {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fieldMessages[field],
+		})
+	}
+	return badRequest
+}
+
+// registeredError will create error of the code, the error is derived from the registered kind or code
+// so it match the registered error, otherwise it only has the code
+func registeredError(code string, kind string, httpCode int, message string) errors.CustomError {
+	if kind == "" {
+		kind = code
+	}
+
+	definition, ok := errors.Lookup(kind)
+	if !ok {
+		return errors.New(code, httpCode, message)
+	}
+
+	customError := errors.New(definition.Code, definition.HTTPCode, message)
+	if code != definition.Code {
+		customError = customError.WithCode(code)
+	}
+	return customError
+}
+
+func restoreErrorInfo(customError *errors.CustomError, metadata map[string]string) {
+	details := make(map[string]interface{})
+	for key, value := range metadata {
+		if key == kindMetadataKey {
+			continue
+		}
+		if key == httpCodeMetadataKey {
+			if httpCode, err := strconv.Atoi(value); err == nil {
+				customError.HTTPCode = httpCode
+			}
+			continue
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			decoded = value
+		}
+		details[key] = decoded
+	}
+	if len(details) > 0 {
+		*customError = customError.WithDetails(details)
+	}
+}
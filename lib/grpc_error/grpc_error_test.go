@@ -0,0 +1,100 @@
+package grpc_error_test
+
+import (
+	"context"
+	goerrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PhantomX7/go-core/lib/grpc_error"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestToStatus(t *testing.T) {
+	st := grpc_error.ToStatus(errors.ErrNotFound.
+		WithCode("ORDER_NOT_FOUND").
+		WithDetails(map[string]interface{}{"order_id": 12}))
+
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "Record not exist", st.Message())
+
+	customError := grpc_error.FromStatus(st)
+	assert.Equal(t, "ORDER_NOT_FOUND", customError.Code)
+	assert.Equal(t, http.StatusNotFound, customError.HTTPCode)
+	assert.Equal(t, "Record not exist", customError.Message)
+	assert.Equal(t, map[string]interface{}{"order_id": float64(12)}, customError.Details())
+
+	// the error of registered code still match the registered error
+	assert.True(t, errors.Is(customError, errors.ErrNotFound))
+	assert.True(t, errors.Is(grpc_error.FromStatus(grpc_error.ToStatus(errors.ErrConflict)), errors.ErrConflict))
+	assert.False(t, errors.Is(grpc_error.FromStatus(grpc_error.ToStatus(errors.ErrConflict)), errors.ErrNotFound))
+}
+
+func TestToStatusWithFieldMessages(t *testing.T) {
+	st := grpc_error.ToStatus(errors.ErrUnprocessableEntity.
+		WithCode("VALIDATION_FAILED").
+		WithMessage(map[string]string{"email": "email is required"}))
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	customError := grpc_error.FromStatus(st)
+	assert.Equal(t, http.StatusUnprocessableEntity, customError.HTTPCode)
+	assert.Equal(t, map[string]string{"email": "email is required"}, customError.Message)
+}
+
+func TestToStatusWithOtherError(t *testing.T) {
+	assert.Equal(t, codes.OK, grpc_error.ToStatus(nil).Code())
+	assert.Equal(t, codes.Internal, grpc_error.ToStatus(goerrors.New("connection refused")).Code())
+	assert.Equal(t, "Tetap Tenang Tetap Semangat", grpc_error.ToStatus(goerrors.New("connection refused")).Message())
+	assert.Equal(t, codes.DeadlineExceeded, grpc_error.ToStatus(context.DeadlineExceeded).Code())
+	assert.Equal(t, codes.Aborted, grpc_error.ToStatus(status.Error(codes.Aborted, "aborted")).Code())
+}
+
+func TestFromError(t *testing.T) {
+	assert.Nil(t, grpc_error.FromError(nil))
+
+	err := grpc_error.FromError(status.Error(codes.PermissionDenied, "denied"))
+	var customError errors.CustomError
+	assert.True(t, errors.As(err, &customError))
+	assert.Equal(t, http.StatusForbidden, customError.HTTPCode)
+	assert.Equal(t, "denied", customError.Message)
+
+	other := goerrors.New("connection refused")
+	assert.Equal(t, other, grpc_error.FromError(other))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := grpc_error.UnaryServerInterceptor()
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.ErrUnauthorized
+		})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	err := grpc_error.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return errors.ErrConflict
+		})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, codes.FailedPrecondition, grpc_error.Code(http.StatusLocked))
+	assert.Equal(t, codes.Unknown, grpc_error.Code(http.StatusBadGateway))
+	assert.Equal(t, http.StatusConflict, grpc_error.HTTPCode(codes.Aborted))
+}
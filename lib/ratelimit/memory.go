@@ -0,0 +1,155 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// memoryEntry is the state of a key in the process, only the field of the algorithm is used
+type memoryEntry struct {
+	// count and resetAt is the state of fixed window
+	count   int
+	resetAt time.Time
+	// log is the time of every allowed request of sliding window log
+	log []time.Time
+	// tokens and refilledAt is the state of token bucket
+	tokens     float64
+	refilledAt time.Time
+
+	// accessedAt is used to remove idle entry
+	accessedAt time.Time
+}
+
+// allowFunc will apply the request to the entry, entry of new key is zero
+type allowFunc func(entry *memoryEntry, now time.Time) Result
+
+// memoryLimiter keep the state in the process, it is used for client of redis.MemoryDriver
+// entry idle longer than the window is the same as new entry, so it is removed periodically
+type memoryLimiter struct {
+	mutex     sync.Mutex
+	entries   map[string]*memoryEntry
+	window    time.Duration
+	sweptAt   time.Time
+	allowFunc allowFunc
+}
+
+func newMemoryLimiter(window time.Duration, fn allowFunc) Limiter {
+	return &memoryLimiter{
+		entries:   make(map[string]*memoryEntry),
+		window:    window,
+		sweptAt:   time.Now(),
+		allowFunc: fn,
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.sweptAt) >= l.window {
+		l.sweep(now)
+	}
+
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		l.entries[key] = entry
+	}
+	entry.accessedAt = now
+	return l.allowFunc(entry, now), nil
+}
+
+// sweep will remove idle entry, the caller must hold the mutex
+func (l *memoryLimiter) sweep(now time.Time) {
+	for key, entry := range l.entries {
+		if now.Sub(entry.accessedAt) >= l.window {
+			delete(l.entries, key)
+		}
+	}
+	l.sweptAt = now
+}
+
+// fixedWindowAllow is the in-process fixedWindowScript
+func fixedWindowAllow(limit int, window time.Duration) allowFunc {
+	return func(entry *memoryEntry, now time.Time) Result {
+		if !now.Before(entry.resetAt) {
+			entry.count = 0
+			entry.resetAt = now.Add(window)
+		}
+		entry.count++
+
+		result := Result{
+			Allowed:    entry.count <= limit,
+			Limit:      limit,
+			Remaining:  max(0, limit-entry.count),
+			ResetAfter: entry.resetAt.Sub(now),
+		}
+		if !result.Allowed {
+			result.RetryAfter = result.ResetAfter
+		}
+		return result
+	}
+}
+
+// slidingWindowLogAllow is the in-process slidingWindowLogScript
+func slidingWindowLogAllow(limit int, window time.Duration) allowFunc {
+	return func(entry *memoryEntry, now time.Time) Result {
+		start := now.Add(-window)
+		for len(entry.log) > 0 && !entry.log[0].After(start) {
+			entry.log = entry.log[1:]
+		}
+
+		allowed := len(entry.log) < limit
+		if allowed {
+			entry.log = append(entry.log, now)
+		}
+
+		result := Result{
+			Allowed:   allowed,
+			Limit:     limit,
+			Remaining: limit - len(entry.log),
+		}
+		if len(entry.log) > 0 {
+			result.ResetAfter = entry.log[len(entry.log)-1].Add(window).Sub(now)
+			if !allowed {
+				result.RetryAfter = entry.log[0].Add(window).Sub(now)
+			}
+		}
+		return result
+	}
+}
+
+// tokenBucketAllow is the in-process tokenBucketScript
+func tokenBucketAllow(capacity int, period time.Duration) allowFunc {
+	// rate is tokens per nanosecond
+	rate := float64(capacity) / float64(period)
+
+	return func(entry *memoryEntry, now time.Time) Result {
+		if entry.refilledAt.IsZero() {
+			entry.tokens = float64(capacity)
+			entry.refilledAt = now
+		}
+		entry.tokens = math.Min(float64(capacity), entry.tokens+float64(now.Sub(entry.refilledAt))*rate)
+		entry.refilledAt = now
+
+		result := Result{
+			Limit: capacity,
+		}
+		if entry.tokens >= 1 {
+			entry.tokens--
+			result.Allowed = true
+		} else {
+			result.RetryAfter = time.Duration(math.Ceil((1 - entry.tokens) / rate))
+		}
+		result.Remaining = int(math.Floor(entry.tokens))
+		result.ResetAfter = time.Duration(math.Ceil((float64(capacity) - entry.tokens) / rate))
+		return result
+	}
+}
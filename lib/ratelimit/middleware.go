@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+// ErrTooManyRequests is written by Middleware when the limit is exceeded
+var ErrTooManyRequests = errors.MustRegister(
+	errors.New("TOO_MANY_REQUESTS", http.StatusTooManyRequests, "Too Many Requests"),
+	"The rate limit is exceeded, retry after the Retry-After header",
+)
+
+func init() {
+	_ = errors.SetTranslation(language.English, ErrTooManyRequests.Code, "Too Many Requests")
+	_ = errors.SetTranslation(language.Indonesian, ErrTooManyRequests.Code, "Terlalu banyak permintaan, silakan coba lagi nanti")
+}
+
+// KeyFunc will return the key of the request to be limited, empty key is not limited
+type KeyFunc func(r *http.Request) string
+
+// Logger is used to log the limiter error, *log.Logger satisfy it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WriterFunc will write the error response, e.g. response_util.WriteError or response_util.WriteProblem
+type WriterFunc func(w http.ResponseWriter, r *http.Request, err error) error
+
+type Config struct {
+	Limiter Limiter
+	// KeyFunc default to IPKey
+	KeyFunc KeyFunc
+	// Writer default to response_util.WriteError
+	Writer WriterFunc
+	// Logger is used to log the limiter error, default to standard logger writing to stderr
+	Logger Logger
+	// FailClosed will reject the request when the limiter fail, by default the request is allowed
+	FailClosed bool
+}
+
+// IPKey will limit the request by the ip address of the remote address
+// the remote address should be set by proxy aware middleware when the server is behind proxy
+func IPKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// HeaderKey will limit the request by the value of the header, e.g. X-API-Key
+func HeaderKey(header string) KeyFunc {
+	return func(r *http.Request) string {
+		return r.Header.Get(header)
+	}
+}
+
+// Middleware will limit the request with the limiter and set the X-RateLimit-* headers
+// exceeded request is answered with ErrTooManyRequests and Retry-After header
+func Middleware(config Config) func(http.Handler) http.Handler {
+	if config.KeyFunc == nil {
+		config.KeyFunc = IPKey
+	}
+	if config.Writer == nil {
+		config.Writer = response_util.WriteError
+	}
+	if config.Logger == nil {
+		config.Logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := config.KeyFunc(r)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := config.Limiter.Allow(r.Context(), key)
+			if err != nil {
+				config.Logger.Printf("error limiting request of %s: %v", key, err)
+				if config.FailClosed {
+					_ = config.Writer(w, r, err)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("X-RateLimit-Reset", strconv.FormatInt(seconds(result.ResetAfter), 10))
+
+			if !result.Allowed {
+				header.Set("Retry-After", strconv.FormatInt(seconds(result.RetryAfter), 10))
+				_ = config.Writer(w, r, ErrTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds will round the duration up, so client does not retry before the limit is reset
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
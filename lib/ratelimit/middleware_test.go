@@ -0,0 +1,136 @@
+package ratelimit_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/ratelimit"
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.ErrServiceUnavailable
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	handler := ratelimit.Middleware(ratelimit.Config{
+		Limiter: ratelimit.NewFixedWindow(client, "ratelimit:http:", 1, 1500*time.Millisecond),
+	})(okHandler())
+
+	request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, "", recorder.Header().Get("Retry-After"))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+	assert.Contains(t, recorder.Body.String(), "TOO_MANY_REQUESTS")
+
+	request.Header.Set("Accept-Language", "id")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Contains(t, recorder.Body.String(), "Terlalu banyak permintaan")
+
+	other := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	other.RemoteAddr = "10.0.0.2:1234"
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, other)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestMiddlewareMemoryClient(t *testing.T) {
+	var logs bytes.Buffer
+	handler := ratelimit.Middleware(ratelimit.Config{
+		Limiter: ratelimit.NewFixedWindow(redis.NewMemoryClient(), "ratelimit:http:", 1, time.Minute),
+		Logger:  log.New(&logs, "", 0),
+	})(okHandler())
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	assert.Empty(t, logs.String())
+}
+
+func TestMiddlewareHeaderKey(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	handler := ratelimit.Middleware(ratelimit.Config{
+		Limiter: ratelimit.NewFixedWindow(client, "ratelimit:http:", 1, time.Minute),
+		KeyFunc: ratelimit.HeaderKey("X-API-Key"),
+	})(okHandler())
+
+	// request without key is not limited
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "", recorder.Header().Get("X-RateLimit-Limit"))
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	request.Header.Set("X-API-Key", "key-1")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
+
+func TestMiddlewareLimiterError(t *testing.T) {
+	var logs bytes.Buffer
+	request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+
+	handler := ratelimit.Middleware(ratelimit.Config{
+		Limiter: failingLimiter{},
+		Logger:  log.New(&logs, "", 0),
+	})(okHandler())
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, logs.String(), "error limiting request of 192.0.2.1")
+
+	handler = ratelimit.Middleware(ratelimit.Config{
+		Limiter:    failingLimiter{},
+		Logger:     log.New(&logs, "", 0),
+		FailClosed: true,
+	})(okHandler())
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
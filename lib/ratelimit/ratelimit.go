@@ -0,0 +1,278 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/PhantomX7/go-core/lib/redis"
+)
+
+// Result is the state of the limit after a request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is the duration until the limit is fully available again
+	ResetAfter time.Duration
+	// RetryAfter is the duration until the next request is allowed, zero when it is allowed
+	RetryAfter time.Duration
+}
+
+// Limiter will count the request of the key, e.g. user id or ip address
+// the state is kept in redis so the limit is shared across instances.
+// client of redis.MemoryDriver can not run script, so every constructor keep the state
+// in the process instead and the limit is per instance
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+// serverTime is prepended to the script that need the current time, now is in milliseconds
+// the time is taken from redis so the clock of every instance does not need to be in sync,
+// replicate_commands allow write after TIME on redis older than 5
+const serverTime = `
+redis.replicate_commands()
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+`
+
+// fixedWindowScript count the request in a window started by the first request
+// KEYS[1] key, ARGV[1] window in milliseconds
+var fixedWindowScript = redis.NewScript(`
+local current = redis.call("INCR", KEYS[1])
+if current == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {current, ttl}
+`)
+
+// slidingWindowLogScript keep the timestamp of every allowed request in the last window
+// KEYS[1] key, ARGV[1] limit, ARGV[2] window in milliseconds, ARGV[3] unique member
+var slidingWindowLogScript = redis.NewScript(serverTime + `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call("PEXPIRE", KEYS[1], window)
+
+-- the limit is fully available when the newest entry leave the window
+-- and the next request is allowed when the oldest entry leave the window
+local reset = 0
+local newest = redis.call("ZRANGE", KEYS[1], -1, -1, "WITHSCORES")
+if newest[2] then
+	reset = tonumber(newest[2]) + window - now
+end
+local retry = 0
+if allowed == 0 then
+	local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+	retry = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset, retry}
+`)
+
+// tokenBucketScript refill the bucket by the elapsed time and take a token
+// KEYS[1] key, ARGV[1] capacity, ARGV[2] refill period of the whole capacity in milliseconds
+var tokenBucketScript = redis.NewScript(serverTime + `
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local rate = capacity / period
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "timestamp")
+local tokens = tonumber(bucket[1])
+local timestamp = tonumber(bucket[2])
+if tokens == nil or timestamp == nil then
+	tokens = capacity
+	timestamp = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - timestamp) * rate)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "timestamp", now)
+redis.call("PEXPIRE", KEYS[1], period)
+return {allowed, math.floor(tokens), math.ceil((capacity - tokens) / rate), retry}
+`)
+
+type fixedWindow struct {
+	client redis.Client
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+// NewFixedWindow will create limiter that allow limit request per window
+// the window start on the first request of the key, so burst up to twice the limit can happen across two windows
+func NewFixedWindow(client redis.Client, prefix string, limit int, window time.Duration) Limiter {
+	if client.Driver() == redis.MemoryDriver {
+		return newMemoryLimiter(window, fixedWindowAllow(limit, window))
+	}
+	return &fixedWindow{
+		client: client,
+		prefix: prefix,
+		limit:  limit,
+		window: window,
+	}
+}
+
+func (l *fixedWindow) Allow(ctx context.Context, key string) (Result, error) {
+	values, err := run(ctx, l.client, fixedWindowScript, fmt.Sprint(l.prefix, key), milliseconds(l.window))
+	if err != nil {
+		return Result{}, err
+	}
+
+	current, ttl := values[0], time.Duration(values[1])*time.Millisecond
+	result := Result{
+		Allowed:    current <= int64(l.limit),
+		Limit:      l.limit,
+		Remaining:  max(0, l.limit-int(current)),
+		ResetAfter: ttl,
+	}
+	if !result.Allowed {
+		result.RetryAfter = ttl
+	}
+	return result, nil
+}
+
+type slidingWindowLog struct {
+	client redis.Client
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+// NewSlidingWindowLog will create limiter that allow limit request in any window
+// the timestamp of every allowed request is kept, so it is exact but use memory per request
+func NewSlidingWindowLog(client redis.Client, prefix string, limit int, window time.Duration) Limiter {
+	if client.Driver() == redis.MemoryDriver {
+		return newMemoryLimiter(window, slidingWindowLogAllow(limit, window))
+	}
+	return &slidingWindowLog{
+		client: client,
+		prefix: prefix,
+		limit:  limit,
+		window: window,
+	}
+}
+
+func (l *slidingWindowLog) Allow(ctx context.Context, key string) (Result, error) {
+	member, err := uniqueMember()
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, err := run(ctx, l.client, slidingWindowLogScript, fmt.Sprint(l.prefix, key),
+		l.limit, milliseconds(l.window), member)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      l.limit,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+type tokenBucket struct {
+	client   redis.Client
+	prefix   string
+	capacity int
+	period   time.Duration
+}
+
+// NewTokenBucket will create limiter with bucket of capacity tokens refilled evenly over the period
+// e.g. capacity 60 and period of a minute allow burst of 60 request and then one request per second
+func NewTokenBucket(client redis.Client, prefix string, capacity int, period time.Duration) Limiter {
+	if client.Driver() == redis.MemoryDriver {
+		return newMemoryLimiter(period, tokenBucketAllow(capacity, period))
+	}
+	return &tokenBucket{
+		client:   client,
+		prefix:   prefix,
+		capacity: capacity,
+		period:   period,
+	}
+}
+
+func (l *tokenBucket) Allow(ctx context.Context, key string) (Result, error) {
+	values, err := run(ctx, l.client, tokenBucketScript, fmt.Sprint(l.prefix, key),
+		l.capacity, milliseconds(l.period))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      l.capacity,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+// run will run the script on the key and return its integer array reply
+func run(ctx context.Context, client redis.Client, script *redis.Script, key string, args ...interface{}) ([]int64, error) {
+	reply, err := client.RunScript(ctx, script, []string{key}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("ratelimit: unexpected script reply %v", reply)
+	}
+
+	values := make([]int64, 0, len(replies))
+	for _, reply := range replies {
+		value, ok := reply.(int64)
+		if !ok {
+			return nil, fmt.Errorf("ratelimit: unexpected script reply %v", replies)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// uniqueMember will return random member of the sliding window log, request of the same millisecond
+// from every instance is kept as different member so it is counted
+func uniqueMember() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("ratelimit: generate member: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func milliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
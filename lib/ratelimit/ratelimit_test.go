@@ -0,0 +1,230 @@
+package ratelimit_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/ratelimit"
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func newClient(t *testing.T) (*miniredis.Miniredis, redis.Client) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	return server, client
+}
+
+func TestFixedWindow(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	limiter := ratelimit.NewFixedWindow(client, "ratelimit:login:", 2, time.Minute)
+	ctx := context.Background()
+
+	result, err := limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Minute}, result)
+
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+
+	result, err = limiter.Allow(ctx, "2")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+
+	server.FastForward(time.Minute)
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
+
+func TestSlidingWindowLog(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	limiter := ratelimit.NewSlidingWindowLog(client, "ratelimit:search:", 2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		result, err := limiter.Allow(ctx, "1")
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, remaining, result.Remaining)
+	}
+
+	result, err := limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 2, result.Limit)
+	assert.True(t, result.RetryAfter > 0 && result.RetryAfter <= 100*time.Millisecond)
+	assert.True(t, result.ResetAfter >= result.RetryAfter)
+
+	time.Sleep(110 * time.Millisecond)
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
+
+func TestTokenBucket(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	limiter := ratelimit.NewTokenBucket(client, "ratelimit:api:", 2, 200*time.Millisecond)
+	ctx := context.Background()
+
+	result, err := limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Equal(t, time.Duration(0), result.RetryAfter)
+
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.False(t, result.Allowed)
+	assert.True(t, result.RetryAfter > 0 && result.RetryAfter <= 100*time.Millisecond)
+	assert.True(t, result.ResetAfter > 100*time.Millisecond && result.ResetAfter <= 200*time.Millisecond)
+
+	// one token is refilled every 100 milliseconds
+	time.Sleep(110 * time.Millisecond)
+	result, err = limiter.Allow(ctx, "1")
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestAllowError(t *testing.T) {
+	server, client := newClient(t)
+	server.Close()
+
+	_, err := ratelimit.NewFixedWindow(client, "ratelimit:login:", 2, time.Minute).Allow(context.Background(), "1")
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	memory := redis.NewMemoryClient(redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	_, err = ratelimit.NewTokenBucket(memory, "ratelimit:api:", 2, time.Minute).Allow(ctx, "1")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestSlidingWindowLogReset(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	memory := redis.NewMemoryClient(redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	for name, limiter := range map[string]ratelimit.Limiter{
+		"redis":  ratelimit.NewSlidingWindowLog(client, "ratelimit:search:", 2, 200*time.Millisecond),
+		"memory": ratelimit.NewSlidingWindowLog(memory, "ratelimit:search:", 2, 200*time.Millisecond),
+	} {
+		_, err := limiter.Allow(context.Background(), "1")
+		assert.Nil(t, err, name)
+		time.Sleep(100 * time.Millisecond)
+
+		// the limit is fully available when the newest request leave the window
+		result, err := limiter.Allow(context.Background(), "1")
+		assert.Nil(t, err, name)
+		assert.True(t, result.ResetAfter > 150*time.Millisecond, name)
+
+		// the next request is allowed when the oldest request leave the window
+		result, err = limiter.Allow(context.Background(), "1")
+		assert.Nil(t, err, name)
+		assert.False(t, result.Allowed, name)
+		assert.True(t, result.RetryAfter > 0 && result.RetryAfter <= 100*time.Millisecond, name)
+		assert.True(t, result.ResetAfter > 150*time.Millisecond, name)
+	}
+}
+
+func TestServerTime(t *testing.T) {
+	server, client := newClient(t)
+	defer server.Close()
+
+	// the clock of redis is used instead of the clock of the process
+	now := time.Now().Add(time.Hour)
+	server.SetTime(now)
+	ctx := context.Background()
+
+	for name, test := range map[string]struct {
+		limiter    ratelimit.Limiter
+		retryAfter time.Duration
+	}{
+		"sliding window log": {ratelimit.NewSlidingWindowLog(client, "ratelimit:search:", 2, time.Minute), time.Minute},
+		"token bucket":       {ratelimit.NewTokenBucket(client, "ratelimit:api:", 2, time.Minute), 30 * time.Second},
+	} {
+		limiter := test.limiter
+		server.SetTime(now)
+		for i := 0; i < 2; i++ {
+			result, err := limiter.Allow(ctx, "1")
+			assert.Nil(t, err, name)
+			assert.True(t, result.Allowed, name)
+		}
+
+		result, err := limiter.Allow(ctx, "1")
+		assert.Nil(t, err, name)
+		assert.False(t, result.Allowed, name)
+		assert.Equal(t, test.retryAfter, result.RetryAfter, name)
+
+		server.SetTime(now.Add(time.Minute))
+		result, err = limiter.Allow(ctx, "1")
+		assert.Nil(t, err, name)
+		assert.True(t, result.Allowed, name)
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	memory := redis.NewMemoryClient(redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	ctx := context.Background()
+
+	for name, limiter := range map[string]ratelimit.Limiter{
+		"fixed window":       ratelimit.NewFixedWindow(memory, "ratelimit:login:", 2, 100*time.Millisecond),
+		"sliding window log": ratelimit.NewSlidingWindowLog(memory, "ratelimit:search:", 2, 100*time.Millisecond),
+		"token bucket":       ratelimit.NewTokenBucket(memory, "ratelimit:api:", 2, 100*time.Millisecond),
+	} {
+		for remaining := 1; remaining >= 0; remaining-- {
+			result, err := limiter.Allow(ctx, "1")
+			assert.Nil(t, err, name)
+			assert.True(t, result.Allowed, name)
+			assert.Equal(t, 2, result.Limit, name)
+			assert.Equal(t, remaining, result.Remaining, name)
+		}
+
+		result, err := limiter.Allow(ctx, "1")
+		assert.Nil(t, err, name)
+		assert.False(t, result.Allowed, name)
+		assert.True(t, result.RetryAfter > 0 && result.RetryAfter <= 100*time.Millisecond, name)
+
+		result, err = limiter.Allow(ctx, "2")
+		assert.Nil(t, err, name)
+		assert.True(t, result.Allowed, name)
+
+		time.Sleep(110 * time.Millisecond)
+		result, err = limiter.Allow(ctx, "1")
+		assert.Nil(t, err, name)
+		assert.True(t, result.Allowed, name)
+	}
+}
@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec will encode object stored by SetObject and decode it on GetObject
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var (
+	// JSONCodec is the default codec
+	JSONCodec Codec = jsonCodec{}
+	// GobCodec encode object with encoding/gob, the type of interface field must be registered with gob.Register
+	GobCodec Codec = gobCodec{}
+	// MsgpackCodec encode object with msgpack, it respects the msgpack struct tag
+	MsgpackCodec Codec = msgpackCodec{}
+)
+
+// Compression is the algorithm used to compress encoded object above the compression threshold
+type Compression byte
+
+// the compression is written as the first byte of the stored value,
+// so value stored with other compression can still be read
+const (
+	NoCompression Compression = iota
+	GzipCompression
+	SnappyCompression
+)
+
+// WithCodec will set the codec of SetObject and GetObject, default to JSONCodec
+func WithCodec(codec Codec) Option {
+	return func(r *Redis) {
+		r.codec = codec
+	}
+}
+
+// WithCompression will compress encoded object which size is at least threshold bytes
+func WithCompression(compression Compression, threshold int) Option {
+	return func(r *Redis) {
+		r.compression = compression
+		r.compressionThreshold = threshold
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(v); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+// compress will prepend the compression byte to the data, data below the threshold is not compressed
+func compress(data []byte, compression Compression, threshold int) ([]byte, error) {
+	if compression == NoCompression || len(data) < threshold {
+		return append([]byte{byte(NoCompression)}, data...), nil
+	}
+
+	switch compression {
+	case GzipCompression:
+		var buffer bytes.Buffer
+		buffer.WriteByte(byte(GzipCompression))
+
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case SnappyCompression:
+		return append([]byte{byte(SnappyCompression)}, snappy.Encode(nil, data)...), nil
+	}
+
+	return nil, fmt.Errorf("unknown compression %d", compression)
+}
+
+// decompress will read the compression byte and decompress the rest of the data
+func decompress(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty cache object")
+	}
+
+	switch Compression(data[0]) {
+	case NoCompression:
+		return data[1:], nil
+	case GzipCompression:
+		reader, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	case SnappyCompression:
+		return snappy.Decode(nil, data[1:])
+	}
+
+	return nil, fmt.Errorf("unknown compression %d", data[0])
+}
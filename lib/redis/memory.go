@@ -0,0 +1,199 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	redsyncredis "github.com/go-redsync/redsync/v4/redis"
+)
+
+type memoryEntry struct {
+	value string
+	// expiresAt is zero when the entry does not expire
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryStore keep the entries in a map, expired entry is removed when it is accessed
+type memoryStore struct {
+	mutex   sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (s *memoryStore) get(ctx context.Context, key string) (string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.lookup(key)
+	return entry.value, ok, nil
+}
+
+func (s *memoryStore) set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.store(key, value, expiration)
+	return nil
+}
+
+func (s *memoryStore) del(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memoryStore) ping(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return "PONG", nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+func (s *memoryStore) driver() string {
+	return MemoryDriver
+}
+
+func (s *memoryStore) pool() redsyncredis.Pool {
+	return memoryPool{store: s}
+}
+
+// lookup will return the entry of the key, the caller must hold the mutex
+func (s *memoryStore) lookup(key string) (memoryEntry, bool) {
+	entry, ok := s.entries[key]
+	if ok && entry.expired(time.Now()) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, ok
+}
+
+// store will set the entry of the key, zero expiration never expire, the caller must hold the mutex
+func (s *memoryStore) store(key string, value string, expiration time.Duration) {
+	entry := memoryEntry{value: value}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+	s.entries[key] = entry
+}
+
+// memoryPool is redsync pool of the memory store, so mutex lock the key within the process
+type memoryPool struct {
+	store *memoryStore
+}
+
+func (p memoryPool) Get(ctx context.Context) (redsyncredis.Conn, error) {
+	if ctx != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return memoryConn{store: p.store}, nil
+}
+
+type memoryConn struct {
+	store *memoryStore
+}
+
+func (c memoryConn) Get(name string) (string, error) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+
+	entry, _ := c.store.lookup(name)
+	return entry.value, nil
+}
+
+func (c memoryConn) Set(name string, value string) (bool, error) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+
+	c.store.store(name, value, 0)
+	return true, nil
+}
+
+func (c memoryConn) SetNX(name string, value string, expiry time.Duration) (bool, error) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+
+	if _, ok := c.store.lookup(name); ok {
+		return false, nil
+	}
+	c.store.store(name, value, expiry)
+	return true, nil
+}
+
+// Eval will run the delete or touch script of redsync mutex, it does not run lua
+// delete script is called with the key and value, touch script is called with the key, value and expiry in milliseconds
+func (c memoryConn) Eval(script *redsyncredis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	if len(keysAndArgs) < 2 {
+		return nil, fmt.Errorf("memory redis does not support script %s", script.Hash)
+	}
+
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+
+	name := fmt.Sprint(keysAndArgs[0])
+	entry, ok := c.store.lookup(name)
+	if !ok || entry.value != fmt.Sprint(keysAndArgs[1]) {
+		return int64(0), nil
+	}
+
+	switch len(keysAndArgs) {
+	case 2:
+		delete(c.store.entries, name)
+	case 3:
+		expiry, ok := keysAndArgs[2].(int)
+		if !ok {
+			return nil, fmt.Errorf("memory redis does not support script %s", script.Hash)
+		}
+		c.store.store(name, entry.value, time.Duration(expiry)*time.Millisecond)
+	default:
+		return nil, fmt.Errorf("memory redis does not support script %s", script.Hash)
+	}
+	return int64(1), nil
+}
+
+func (c memoryConn) PTTL(name string) (time.Duration, error) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+
+	entry, ok := c.store.lookup(name)
+	if !ok {
+		// same as redis, -2 when the key does not exist and -1 when it has no expiry
+		return -2 * time.Millisecond, nil
+	}
+	if entry.expiresAt.IsZero() {
+		return -1 * time.Millisecond, nil
+	}
+	return time.Until(entry.expiresAt), nil
+}
+
+func (c memoryConn) Close() error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestMemoryClient(t *testing.T) {
+	client := redis.NewClient(redis.Credentials{
+		Driver: redis.MemoryDriver,
+	}, "production", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+
+	assert.Nil(t, client.Ping())
+	assert.Equal(t, redis.MemoryDriver, client.Driver())
+	assert.Nil(t, client.Set("order:", "1", "paid", 50*time.Millisecond))
+	assert.Nil(t, client.Set("order:", "2", "unpaid", 0))
+	assert.Equal(t, "paid", client.Get("order:", "1"))
+	assert.Equal(t, "", client.Get("payment:", "1"))
+
+	time.Sleep(60 * time.Millisecond)
+	_, found, err := client.Find("order:", "1")
+	assert.False(t, found)
+	assert.True(t, errors.Is(err, redis.ErrCacheMiss))
+	assert.Equal(t, "unpaid", client.Get("order:", "2"))
+
+	assert.Nil(t, client.Delete("order:", "2"))
+	assert.Equal(t, "", client.Get("order:", "2"))
+
+	assert.Nil(t, client.SetObject("order:", "3", order{ID: 3, Status: "paid"}, time.Minute))
+	var result order
+	assert.Nil(t, client.GetObject("order:", "3", &result))
+	assert.Equal(t, order{ID: 3, Status: "paid"}, result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.True(t, errors.Is(client.SetContext(ctx, "order:", "4", "paid", 0), errors.ErrClientClosedRequest))
+}
+
+func TestMemoryClientMutex(t *testing.T) {
+	client := redis.NewMemoryClient(redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+
+	mutex, err := client.LockContext(context.Background(), "order:1", redsync.WithExpiry(time.Second))
+	assert.Nil(t, err)
+
+	_, err = client.LockContext(context.Background(), "order:1", redsync.WithTries(1))
+	assert.Equal(t, redsync.ErrFailed, err)
+
+	extended, err := mutex.Extend()
+	assert.Nil(t, err)
+	assert.True(t, extended)
+
+	unlocked, err := mutex.Unlock()
+	assert.Nil(t, err)
+	assert.True(t, unlocked)
+
+	_, err = client.LockContext(context.Background(), "order:1", redsync.WithTries(1))
+	assert.Nil(t, err)
+}
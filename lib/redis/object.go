@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func (r *Redis) SetObject(prefix string, key string, value interface{}, expirationTime time.Duration) error {
+	return r.SetObjectContext(context.Background(), prefix, key, value, expirationTime)
+}
+
+// SetObjectContext will encode the value with the codec of the client and store it on the key
+func (r *Redis) SetObjectContext(ctx context.Context, prefix string, key string, value interface{}, expirationTime time.Duration) error {
+	data, err := r.encode(value)
+	if err != nil {
+		r.logger.Printf("error encoding redis key %s%s: %v", prefix, key, err)
+		return errors.Wrap(ErrCacheCodec, err, "")
+	}
+
+	return r.SetContext(ctx, prefix, key, string(data), expirationTime)
+}
+
+func (r *Redis) GetObject(prefix string, key string, dest interface{}) error {
+	return r.GetObjectContext(context.Background(), prefix, key, dest)
+}
+
+// GetObjectContext will decode the value of the key into dest, dest must be a pointer
+// ErrCacheMiss is returned when the key does not exist or it is negatively cached by Remember
+func (r *Redis) GetObjectContext(ctx context.Context, prefix string, key string, dest interface{}) error {
+	value, _, err := r.FindContext(ctx, prefix, key)
+	if err != nil {
+		return err
+	}
+	if value == negativeValue {
+		return ErrCacheMiss
+	}
+
+	if err := r.decode([]byte(value), dest); err != nil {
+		r.logger.Printf("error decoding redis key %s%s: %v", prefix, key, err)
+		return errors.Wrap(ErrCacheCodec, err, "")
+	}
+	return nil
+}
+
+func (r *Redis) encode(value interface{}) ([]byte, error) {
+	data, err := r.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return compress(data, r.compression, r.compressionThreshold)
+}
+
+func (r *Redis) decode(data []byte, dest interface{}) error {
+	data, err := decompress(data)
+	if err != nil {
+		return err
+	}
+	return r.codec.Unmarshal(data, dest)
+}
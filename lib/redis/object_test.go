@@ -0,0 +1,92 @@
+package redis_test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+type order struct {
+	ID     int
+	Status string
+	Notes  string
+}
+
+func TestObject(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		options []redis.Option
+	}{
+		{name: "json", options: []redis.Option{}},
+		{name: "gob with gzip", options: []redis.Option{
+			redis.WithCodec(redis.GobCodec),
+			redis.WithCompression(redis.GzipCompression, 64),
+		}},
+		{name: "msgpack with snappy", options: []redis.Option{
+			redis.WithCodec(redis.MsgpackCodec),
+			redis.WithCompression(redis.SnappyCompression, 64),
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := append(test.options, redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+			client := redis.NewClient(redis.Credentials{
+				Host: server.Host(),
+				Port: server.Port(),
+			}, "development", options...)
+
+			for _, value := range []order{
+				{ID: 1, Status: "paid"},
+				{ID: 2, Status: "paid", Notes: strings.Repeat("fragile ", 32)},
+			} {
+				assert.Nil(t, client.SetObject("order:", test.name, value, time.Minute))
+
+				var result order
+				assert.Nil(t, client.GetObject("order:", test.name, &result))
+				assert.Equal(t, value, result)
+			}
+
+			var result order
+			err := client.GetObject("order:", "missing", &result)
+			assert.True(t, errors.Is(err, redis.ErrCacheMiss))
+		})
+	}
+}
+
+func TestObjectCompression(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development",
+		redis.WithCompression(redis.GzipCompression, 64),
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+	)
+
+	value := order{ID: 1, Notes: strings.Repeat("fragile ", 128)}
+	assert.Nil(t, client.SetObject("order:", "1", value, time.Minute))
+
+	stored, _ := server.Get("order:1")
+	assert.Equal(t, byte(redis.GzipCompression), stored[0])
+	assert.Less(t, len(stored), len(value.Notes))
+
+	assert.Nil(t, client.Set("order:", "2", "not encoded", time.Minute))
+	var result order
+	assert.True(t, errors.Is(client.GetObject("order:", "2", &result), redis.ErrCacheCodec))
+}
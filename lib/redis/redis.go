@@ -1,124 +1,310 @@
 package redis
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
-	redisClient "github.com/go-redis/redis"
-	"github.com/go-redsync/redsync/v3"
-	"github.com/go-redsync/redsync/v3/redis"
-	"github.com/go-redsync/redsync/v3/redis/goredis"
+	redisClient "github.com/go-redis/redis/v8"
+	"github.com/go-redsync/redsync/v4"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/PhantomX7/go-core/utility/errors"
 )
 
+// driver of Credentials
+const (
+	RedisDriver  = "redis"
+	MemoryDriver = "memory"
+)
+
+// Credentials holds the redis address and connection option
+// zero timeout and pool size use the go-redis default
+// sentinel is used when MasterName is set, cluster is used when ClusterAddrs is set,
+// otherwise single node of Host and Port
 type Credentials struct {
+	// Driver default to RedisDriver, MemoryDriver use in-process client that ignore the other field
+	Driver   string
 	Host     string
 	Port     string
+	Username string
 	Password string
+	// DB is ignored by cluster
+	DB int
+
+	// MasterName is the sentinel master name
+	MasterName       string
+	SentinelAddrs    []string
+	SentinelPassword string
+
+	// ClusterAddrs is the seed address of the cluster nodes
+	ClusterAddrs []string
+
+	// TLS will connect with default tls config, TLSConfig override it
+	TLS       bool
+	TLSConfig *tls.Config
+
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	PoolSize     int
+}
+
+// ErrCacheMiss is returned by Find when the key does not exist
+var ErrCacheMiss = errors.MustRegister(
+	errors.ErrNotFound.WithCode("CACHE_MISS").WithMessage("Cache miss"),
+	"The cache key does not exist or it is expired",
+)
+
+// ErrCacheCodec is returned by SetObject and GetObject when the object can not be encoded or decoded
+var ErrCacheCodec = errors.MustRegister(
+	errors.ErrTetapTenangTetapSemangat.WithCode("CACHE_CODEC"),
+	"The cache object can not be encoded or decoded by the codec",
+)
+
+// Logger is used to log redis diagnostic, *log.Logger satisfy it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option configure the client created by NewClient
+type Option func(r *Redis)
+
+// WithLogger will set the logger of the client, default to standard logger writing to stderr
+func WithLogger(logger Logger) Option {
+	return func(r *Redis) {
+		r.logger = logger
+	}
 }
 
+// Client method with Context suffix propagate the deadline and cancellation of ctx to redis,
+// the other method use context.Background
 type Client interface {
 	Get(prefix string, key string) string
+	GetContext(ctx context.Context, prefix string, key string) string
+	Find(prefix string, key string) (string, bool, error)
+	FindContext(ctx context.Context, prefix string, key string) (string, bool, error)
+	GetObject(prefix string, key string, dest interface{}) error
+	GetObjectContext(ctx context.Context, prefix string, key string, dest interface{}) error
 	Set(prefix string, key string, value string, expirationTime time.Duration) error
+	SetContext(ctx context.Context, prefix string, key string, value string, expirationTime time.Duration) error
+	SetObject(prefix string, key string, value interface{}, expirationTime time.Duration) error
+	SetObjectContext(ctx context.Context, prefix string, key string, value interface{}, expirationTime time.Duration) error
+	Remember(ctx context.Context, prefix string, key string, ttl time.Duration, loader Loader, dest interface{}) error
 	Delete(prefix string, key string) error
+	DeleteContext(ctx context.Context, prefix string, key string) error
 	Ping() error
+	PingContext(ctx context.Context) error
 	Close() error
-	NewMutex(key string) *redsync.Mutex
+	Driver() string
+	RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error)
+	NewMutex(key string, options ...redsync.Option) *redsync.Mutex
+	LockContext(ctx context.Context, key string, options ...redsync.Option) (*redsync.Mutex, error)
 }
 
 type Redis struct {
-	client  *redisClient.Client
+	store   store
 	redsync *redsync.Redsync
+	logger  Logger
+
+	codec                Codec
+	compression          Compression
+	compressionThreshold int
+
+	group               singleflight.Group
+	negativeTTL         time.Duration
+	ttlJitter           float64
+	rememberLock        bool
+	rememberLockOptions []redsync.Option
+	rememberTimeout     time.Duration
 }
 
-func NewClient(credentials Credentials, appEnv string) Client {
-	client := redisClient.NewClient(&redisClient.Options{
-		Addr:     fmt.Sprintf("%s:%s", credentials.Host, credentials.Port),
-		Password: credentials.Password,
-		DB:       0,
-	})
-	status := client.Ping()
+// NewClient will create redis client of the credentials,
+// MemoryDriver create in-process client that does not connect to redis
+func NewClient(credentials Credentials, appEnv string, options ...Option) Client {
+	if credentials.Driver == MemoryDriver {
+		return NewMemoryClient(options...)
+	}
+
+	client := newUniversalClient(credentials)
+
+	r := newRedis(&redisStore{client: client}, options)
+	status := client.Ping(context.Background())
 	if status.Err() != nil {
 		if appEnv != "development" {
-			log.Panic(status.Err())
+			r.logger.Printf("redis: %v", status.Err())
+			panic(status.Err())
 		} else {
-			log.Println("warning: redis not connected")
+			r.logger.Printf("warning: redis not connected")
 		}
 	}
 
-	pool := goredis.NewGoredisPool(client)
-	rs := redsync.New([]redis.Pool{pool})
+	return r
+}
 
-	return &Redis{
-		client:  client,
-		redsync: rs,
+// NewMemoryClient will create in-process client for test and local development
+// it supports expiration and mutex within the process, the value is lost when the process exit
+func NewMemoryClient(options ...Option) Client {
+	return newRedis(newMemoryStore(), options)
+}
+
+// newUniversalClient will create sentinel, cluster or single node client of the credentials
+func newUniversalClient(credentials Credentials) redisClient.UniversalClient {
+	options := &redisClient.UniversalOptions{
+		Addrs:            []string{fmt.Sprintf("%s:%s", credentials.Host, credentials.Port)},
+		DB:               credentials.DB,
+		Username:         credentials.Username,
+		Password:         credentials.Password,
+		SentinelPassword: credentials.SentinelPassword,
+		MasterName:       credentials.MasterName,
+		DialTimeout:      credentials.DialTimeout,
+		ReadTimeout:      credentials.ReadTimeout,
+		WriteTimeout:     credentials.WriteTimeout,
+		PoolSize:         credentials.PoolSize,
+		TLSConfig:        credentials.TLSConfig,
+	}
+	if options.TLSConfig == nil && credentials.TLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	switch {
+	case credentials.MasterName != "":
+		options.Addrs = credentials.SentinelAddrs
+		return redisClient.NewFailoverClient(options.Failover())
+	case len(credentials.ClusterAddrs) > 0:
+		options.Addrs = credentials.ClusterAddrs
+		return redisClient.NewClusterClient(options.Cluster())
+	default:
+		return redisClient.NewClient(options.Simple())
 	}
 }
 
-func (r *Redis) Get(prefix string, key string) string {
-	if r.client == nil {
-		log.Println("warning: redis not connected")
-		return ""
+func newRedis(store store, options []Option) *Redis {
+	r := &Redis{
+		store:   store,
+		redsync: redsync.New(store.pool()),
+		logger:  log.New(os.Stderr, "", log.LstdFlags),
+		codec:   JSONCodec,
+
+		rememberTimeout: defaultRememberTimeout,
+	}
+	for _, option := range options {
+		option(r)
 	}
+	return r
+}
+
+func (r *Redis) Get(prefix string, key string) string {
+	return r.GetContext(context.Background(), prefix, key)
+}
 
-	val, err := r.client.Get(fmt.Sprint(prefix, key)).Result()
+func (r *Redis) GetContext(ctx context.Context, prefix string, key string) string {
+	val, _, err := r.store.get(ctx, fmt.Sprint(prefix, key))
 	if err != nil {
-		fmt.Println(err)
+		r.logger.Printf("error getting redis key %s%s: %v", prefix, key, err)
 	}
 	return val
 }
 
-func (r *Redis) Set(prefix string, key string, value string, expirationTime time.Duration) error {
-	if r.client == nil {
-		log.Println("warning: redis not connected")
-		return errors.ErrUnprocessableEntity
+func (r *Redis) Find(prefix string, key string) (string, bool, error) {
+	return r.FindContext(context.Background(), prefix, key)
+}
+
+// FindContext will return the value of the key and whether it is found
+// ErrCacheMiss is returned when the key does not exist, so it can be told apart from redis failure
+func (r *Redis) FindContext(ctx context.Context, prefix string, key string) (string, bool, error) {
+	val, found, err := r.store.get(ctx, fmt.Sprint(prefix, key))
+	if err != nil {
+		r.logger.Printf("error getting redis key %s%s: %v", prefix, key, err)
+		return "", false, contextError(ctx, errors.Wrap(errors.ErrServiceUnavailable, err, ""))
 	}
+	if !found {
+		return "", false, ErrCacheMiss
+	}
+	return val, true, nil
+}
 
-	err := r.client.Set(fmt.Sprint(prefix, key), value, expirationTime).Err()
+func (r *Redis) Set(prefix string, key string, value string, expirationTime time.Duration) error {
+	return r.SetContext(context.Background(), prefix, key, value, expirationTime)
+}
+
+func (r *Redis) SetContext(ctx context.Context, prefix string, key string, value string, expirationTime time.Duration) error {
+	err := r.store.set(ctx, fmt.Sprint(prefix, key), value, expirationTime)
 	if err != nil {
-		fmt.Println(err)
-		return errors.ErrUnprocessableEntity
+		r.logger.Printf("error setting redis key %s%s: %v", prefix, key, err)
+		return contextError(ctx, errors.ErrUnprocessableEntity)
 	}
 
 	return nil
 }
 
 func (r *Redis) Delete(prefix string, key string) error {
-	if r.client == nil {
-		log.Println("warning: redis not connected")
-		return errors.ErrUnprocessableEntity
-	}
+	return r.DeleteContext(context.Background(), prefix, key)
+}
 
-	err := r.client.Del(fmt.Sprint(prefix, key)).Err()
+func (r *Redis) DeleteContext(ctx context.Context, prefix string, key string) error {
+	err := r.store.del(ctx, fmt.Sprint(prefix, key))
 	if err != nil {
-		fmt.Println(err)
+		r.logger.Printf("error deleting redis key %s%s: %v", prefix, key, err)
+		return contextError(ctx, err)
 	}
-	return err
+	return nil
 }
 
 func (r *Redis) Ping() error {
-	pong, err := r.client.Ping().Result()
-	if err != nil {
-		log.Println("error pinging redis:", err)
-		return errors.ErrUnprocessableEntity
+	return r.PingContext(context.Background())
+}
 
+func (r *Redis) PingContext(ctx context.Context) error {
+	pong, err := r.store.ping(ctx)
+	if err != nil {
+		r.logger.Printf("error pinging redis: %v", err)
+		return contextError(ctx, errors.ErrUnprocessableEntity)
 	}
 
-	fmt.Println("connected to redis:", pong)
+	r.logger.Printf("connected to redis: %s", pong)
 	return nil
 }
 
+// Driver will return RedisDriver or MemoryDriver, e.g. to use in-process fallback when script is not supported
+func (r *Redis) Driver() string {
+	return r.store.driver()
+}
+
 func (r *Redis) Close() error {
-	err := r.client.Close()
+	err := r.store.close()
 	if err != nil {
-		log.Println("error closing redis:", err)
+		r.logger.Printf("error closing redis: %v", err)
 		return err
 	}
 	return nil
 }
 
-func (r *Redis) NewMutex(key string) *redsync.Mutex {
-	return r.redsync.NewMutex(key)
+// NewMutex will create redsync mutex of the key, the mutex and options are of github.com/go-redsync/redsync/v4
+func (r *Redis) NewMutex(key string, options ...redsync.Option) *redsync.Mutex {
+	return r.redsync.NewMutex(key, options...)
+}
+
+// LockContext will acquire the mutex of the key, retrying until it is acquired, the tries run out or ctx is done
+func (r *Redis) LockContext(ctx context.Context, key string, options ...redsync.Option) (*redsync.Mutex, error) {
+	mutex := r.redsync.NewMutex(key, options...)
+	if err := mutex.LockContext(ctx); err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return mutex, nil
+}
+
+// contextError will return error of the done context, otherwise err is returned
+// context.Canceled become errors.ErrClientClosedRequest and context.DeadlineExceeded become errors.ErrGatewayTimeout
+func contextError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return errors.Wrap(errors.ErrClientClosedRequest, ctx.Err(), "")
+	case context.DeadlineExceeded:
+		return errors.Wrap(errors.ErrGatewayTimeout, ctx.Err(), "")
+	}
+	return err
 }
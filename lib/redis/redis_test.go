@@ -0,0 +1,139 @@
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestContextError(t *testing.T) {
+	client := redis.NewClient(redis.Credentials{
+		Host:        "127.0.0.1",
+		Port:        "1",
+		DialTimeout: 100 * time.Millisecond,
+	}, "development", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.SetContext(ctx, "order:", "1", "paid", time.Minute)
+	assert.True(t, errors.Is(err, errors.ErrClientClosedRequest))
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err = client.LockContext(ctx, "order:1")
+	assert.True(t, errors.Is(err, errors.ErrGatewayTimeout))
+
+	assert.True(t, errors.Is(client.Ping(), errors.ErrUnprocessableEntity))
+}
+
+func TestFind(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development", redis.WithLogger(log.New(&logs, "", 0)))
+
+	value, found, err := client.Find("order:", "1")
+	assert.Equal(t, "", value)
+	assert.False(t, found)
+	assert.True(t, errors.Is(err, redis.ErrCacheMiss))
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+
+	assert.Nil(t, client.Set("order:", "1", "", time.Minute))
+	value, found, err = client.Find("order:", "1")
+	assert.Equal(t, "", value)
+	assert.True(t, found)
+	assert.Nil(t, err)
+
+	server.Close()
+	_, found, err = client.Find("order:", "1")
+	assert.False(t, found)
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable))
+	assert.Contains(t, logs.String(), "error getting redis key order:1")
+}
+
+func TestNewClientWithDBAndUsername(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+	server.RequireUserAuth("app", "secret")
+
+	client := redis.NewClient(redis.Credentials{
+		Host:     server.Host(),
+		Port:     server.Port(),
+		Username: "app",
+		Password: "secret",
+		DB:       2,
+	}, "production", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	defer client.Close()
+
+	assert.Nil(t, client.Set("order:", "1", "paid", time.Minute))
+	value, err := server.DB(2).Get("order:1")
+	assert.Nil(t, err)
+	assert.Equal(t, "paid", value)
+	assert.False(t, server.Exists("order:1"))
+
+	_, err = client.LockContext(context.Background(), "lock:order:1")
+	assert.Nil(t, err)
+	assert.True(t, server.DB(2).Exists("lock:order:1"))
+}
+
+func TestNewClientWithSentinel(t *testing.T) {
+	client := redis.NewClient(redis.Credentials{
+		MasterName:    "mymaster",
+		SentinelAddrs: []string{"127.0.0.1:1"},
+		DialTimeout:   100 * time.Millisecond,
+	}, "development", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+
+	assert.True(t, errors.Is(client.Ping(), errors.ErrUnprocessableEntity))
+	assert.Panics(t, func() {
+		redis.NewClient(redis.Credentials{
+			ClusterAddrs: []string{"127.0.0.1:1"},
+			DialTimeout:  100 * time.Millisecond,
+		}, "production", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	})
+}
+
+func TestRunScript(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development", redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+
+	assert.Equal(t, redis.RedisDriver, client.Driver())
+
+	script := redis.NewScript(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)
+	result, err := client.RunScript(context.Background(), script, []string{"counter:1"}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), result)
+
+	result, err = client.RunScript(context.Background(), script, []string{"counter:1"}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), result)
+
+	_, err = client.RunScript(context.Background(), redis.NewScript(`return redis.call("UNKNOWN")`), nil)
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable))
+
+	memory := redis.NewMemoryClient(redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+	_, err = memory.RunScript(context.Background(), script, []string{"counter:1"}, 2)
+	assert.True(t, errors.Is(err, redis.ErrScriptNotSupported))
+}
@@ -0,0 +1,162 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// negativeValue is stored by Remember when the loader return errors.ErrNotFound
+// it can not be produced by encode because the first byte is the compression
+const negativeValue = "\xff"
+
+// rememberLockPrefix is prepended to the key of the mutex used by Remember across instances
+const rememberLockPrefix = "lock:remember:"
+
+// defaultRememberTimeout is the timeout of the shared load of Remember, see WithRememberTimeout
+const defaultRememberTimeout = 30 * time.Second
+
+// Loader will load the value of Remember when it is not cached, e.g. from the database
+type Loader func() (interface{}, error)
+
+// WithNegativeCache will make Remember cache errors.ErrNotFound returned by the loader for ttl
+// so missing record does not hit the database on every request
+func WithNegativeCache(ttl time.Duration) Option {
+	return func(r *Redis) {
+		r.negativeTTL = ttl
+	}
+}
+
+// WithTTLJitter will add random duration up to fraction of the ttl to the ttl of Remember
+// e.g. 0.1 with 10 minutes ttl expire the key between 10 and 11 minutes, so hot keys do not expire together
+func WithTTLJitter(fraction float64) Option {
+	return func(r *Redis) {
+		r.ttlJitter = fraction
+	}
+}
+
+// WithRememberTimeout will set the timeout of locking and caching the value loaded by Remember
+// the load is shared by every caller of the key, so it does not use the context of any caller
+func WithRememberTimeout(timeout time.Duration) Option {
+	return func(r *Redis) {
+		r.rememberTimeout = timeout
+	}
+}
+
+// WithRememberLock will make Remember hold redsync mutex of the key while loading,
+// so only one instance load the value, the other wait and read it from the cache
+func WithRememberLock(options ...redsync.Option) Option {
+	return func(r *Redis) {
+		r.rememberLock = true
+		r.rememberLockOptions = options
+	}
+}
+
+// Remember will decode the cached value of the key into dest, dest must be a pointer
+// when it is not cached, the loader is called once per key in this process and its value is cached for ttl
+// redis failure is logged and the loader is called, so the cache does not fail the request
+// ctx only bound the wait of this caller, the shared load keep running when it is done
+// so the value is still cached for the other callers, the loader should use its own timeout
+func (r *Redis) Remember(ctx context.Context, prefix string, key string, ttl time.Duration, loader Loader, dest interface{}) error {
+	value, _, err := r.FindContext(ctx, prefix, key)
+	if err == nil {
+		return r.remembered([]byte(value), dest)
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		if ctx.Err() != nil {
+			return err
+		}
+		r.logger.Printf("error remembering redis key %s%s: %v", prefix, key, err)
+	}
+
+	result := r.group.DoChan(fmt.Sprint(prefix, key), func() (value interface{}, err error) {
+		// DoChan panic on its own goroutine, so panic of the loader would crash the process
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				r.logger.Printf("error loading redis key %s%s: panic: %v", prefix, key, recovered)
+				value, err = nil, errors.Wrap(errors.ErrTetapTenangTetapSemangat,
+					fmt.Errorf("redis: remember loader panic: %v", recovered), "")
+			}
+		}()
+
+		loadCtx, cancel := context.WithTimeout(context.Background(), r.rememberTimeout)
+		defer cancel()
+		return r.load(loadCtx, prefix, key, ttl, loader)
+	})
+
+	select {
+	case <-ctx.Done():
+		return contextError(ctx, ctx.Err())
+	case res := <-result:
+		if res.Err != nil {
+			return res.Err
+		}
+		return r.remembered(res.Val.([]byte), dest)
+	}
+}
+
+// load will call the loader and cache its value, the encoded value is shared with the waiting callers
+func (r *Redis) load(ctx context.Context, prefix string, key string, ttl time.Duration, loader Loader) ([]byte, error) {
+	if r.rememberLock {
+		mutex, err := r.LockContext(ctx, rememberLockPrefix+fmt.Sprint(prefix, key), r.rememberLockOptions...)
+		if err != nil {
+			r.logger.Printf("error locking redis key %s%s: %v", prefix, key, err)
+		} else {
+			defer func() {
+				if _, err := mutex.Unlock(); err != nil {
+					r.logger.Printf("error unlocking redis key %s%s: %v", prefix, key, err)
+				}
+			}()
+
+			// other instance may have loaded the value while this instance wait for the lock
+			if value, found, _ := r.FindContext(ctx, prefix, key); found {
+				return []byte(value), nil
+			}
+		}
+	}
+
+	value, err := loader()
+	if err != nil {
+		if r.negativeTTL > 0 && errors.Is(err, errors.ErrNotFound) {
+			if err := r.SetContext(ctx, prefix, key, negativeValue, r.negativeTTL); err != nil {
+				r.logger.Printf("error remembering redis key %s%s: %v", prefix, key, err)
+			}
+		}
+		return nil, err
+	}
+
+	data, err := r.encode(value)
+	if err != nil {
+		r.logger.Printf("error encoding redis key %s%s: %v", prefix, key, err)
+		return nil, errors.Wrap(ErrCacheCodec, err, "")
+	}
+
+	if err := r.SetContext(ctx, prefix, key, string(data), r.jitter(ttl)); err != nil {
+		r.logger.Printf("error remembering redis key %s%s: %v", prefix, key, err)
+	}
+	return data, nil
+}
+
+// remembered will decode the cached value, negative value become errors.ErrNotFound
+func (r *Redis) remembered(data []byte, dest interface{}) error {
+	if string(data) == negativeValue {
+		return errors.ErrNotFound
+	}
+
+	if err := r.decode(data, dest); err != nil {
+		return errors.Wrap(ErrCacheCodec, err, "")
+	}
+	return nil
+}
+
+func (r *Redis) jitter(ttl time.Duration) time.Duration {
+	if r.ttlJitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Float64()*r.ttlJitter*float64(ttl))
+}
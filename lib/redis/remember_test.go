@@ -0,0 +1,171 @@
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/lib/redis"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestRemember(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development",
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+		redis.WithTTLJitter(0.5),
+		redis.WithRememberLock(),
+	)
+
+	var calls int32
+	loader := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return order{ID: 1, Status: "paid"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var result order
+			assert.Nil(t, client.Remember(context.Background(), "order:", "1", time.Minute, loader, &result))
+			assert.Equal(t, order{ID: 1, Status: "paid"}, result)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.True(t, server.TTL("order:1") >= time.Minute)
+	assert.True(t, server.TTL("order:1") <= 90*time.Second)
+	assert.False(t, server.Exists("lock:remember:order:1"))
+}
+
+func TestRememberNegativeCache(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development",
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+		redis.WithNegativeCache(10*time.Second),
+	)
+
+	calls := 0
+	loader := func() (interface{}, error) {
+		calls++
+		return nil, errors.Wrap(errors.ErrNotFound, nil, "order 1")
+	}
+
+	var result order
+	err = client.Remember(context.Background(), "order:", "1", time.Minute, loader, &result)
+	assert.Equal(t, "Record not exist: order 1", err.Error())
+
+	err = client.Remember(context.Background(), "order:", "1", time.Minute, loader, &result)
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 10*time.Second, server.TTL("order:1"))
+
+	// other error is not cached
+	err = client.Remember(context.Background(), "order:", "2", time.Minute, func() (interface{}, error) {
+		return nil, errors.ErrServiceUnavailable
+	}, &result)
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable))
+	assert.False(t, server.Exists("order:2"))
+}
+
+func TestRememberCanceledCaller(t *testing.T) {
+	server, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(redis.Credentials{
+		Host: server.Host(),
+		Port: server.Port(),
+	}, "development",
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+		redis.WithRememberLock(),
+		redis.WithRememberTimeout(time.Second),
+	)
+
+	var calls int32
+	loader := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return order{ID: 1, Status: "paid"}, nil
+	}
+
+	// the first caller is canceled while the value is loaded
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		var result order
+		done <- client.Remember(ctx, "order:", "1", time.Minute, loader, &result)
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	var result order
+	assert.Nil(t, client.Remember(context.Background(), "order:", "1", time.Minute, loader, &result))
+	assert.Equal(t, order{ID: 1, Status: "paid"}, result)
+	assert.True(t, errors.Is(<-done, errors.ErrGatewayTimeout))
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.True(t, server.Exists("order:1"))
+}
+
+func TestGetObjectNegativeCache(t *testing.T) {
+	client := redis.NewMemoryClient(
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+		redis.WithNegativeCache(time.Minute),
+	)
+
+	var result order
+	err := client.Remember(context.Background(), "order:", "1", time.Minute, func() (interface{}, error) {
+		return nil, errors.ErrNotFound
+	}, &result)
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+
+	err = client.GetObject("order:", "1", &result)
+	assert.True(t, errors.Is(err, redis.ErrCacheMiss))
+}
+
+func TestRememberLoaderPanic(t *testing.T) {
+	client := redis.NewMemoryClient(
+		redis.WithLogger(log.New(&bytes.Buffer{}, "", 0)),
+		redis.WithRememberLock(),
+	)
+
+	var result order
+	err := client.Remember(context.Background(), "order:", "1", time.Minute, func() (interface{}, error) {
+		panic("database is gone")
+	}, &result)
+	assert.True(t, errors.Is(err, errors.ErrTetapTenangTetapSemangat))
+	assert.Contains(t, err.Error(), "redis: remember loader panic: database is gone")
+
+	// the lock is released, so the key can be loaded again
+	err = client.Remember(context.Background(), "order:", "1", time.Minute, func() (interface{}, error) {
+		return order{ID: 1, Status: "paid"}, nil
+	}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, order{ID: 1, Status: "paid"}, result)
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	redisClient "github.com/go-redis/redis/v8"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// ErrScriptNotSupported is returned by RunScript of client that can not run lua script, e.g. memory client
+var ErrScriptNotSupported = errors.MustRegister(
+	errors.ErrTetapTenangTetapSemangat.WithCode("CACHE_SCRIPT_NOT_SUPPORTED"),
+	"The redis driver can not run lua script",
+)
+
+// Script is lua script run atomically by RunScript, create it once as package variable
+type Script struct {
+	script *redisClient.Script
+}
+
+// NewScript will create script of the lua source
+func NewScript(src string) *Script {
+	return &Script{
+		script: redisClient.NewScript(src),
+	}
+}
+
+// RunScript will run the script with EVALSHA and fallback to EVAL when the script is not loaded
+// keys are not prefixed, the prefix must be included by the caller
+func (r *Redis) RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := r.store.eval(ctx, script, keys, args)
+	if err != nil {
+		if errors.Is(err, ErrScriptNotSupported) {
+			return nil, err
+		}
+		r.logger.Printf("error running redis script on %v: %v", keys, err)
+		return nil, contextError(ctx, errors.Wrap(errors.ErrServiceUnavailable, err, ""))
+	}
+	return result, nil
+}
+
+func (s *redisStore) eval(ctx context.Context, script *Script, keys []string, args []interface{}) (interface{}, error) {
+	result, err := script.script.Run(ctx, s.client, keys, args...).Result()
+	if err == redisClient.Nil {
+		return nil, nil
+	}
+	return result, err
+}
+
+func (s *memoryStore) eval(ctx context.Context, script *Script, keys []string, args []interface{}) (interface{}, error) {
+	return nil, errors.Wrap(ErrScriptNotSupported, fmt.Errorf("memory redis can not run script on %v", keys), "")
+}
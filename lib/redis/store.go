@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	redisClient "github.com/go-redis/redis/v8"
+	redsyncredis "github.com/go-redsync/redsync/v4/redis"
+	"github.com/go-redsync/redsync/v4/redis/goredis/v8"
+)
+
+// store is the storage of Redis, key is already prefixed
+type store interface {
+	// get will return false without error when the key does not exist
+	get(ctx context.Context, key string) (string, bool, error)
+	set(ctx context.Context, key string, value string, expiration time.Duration) error
+	del(ctx context.Context, key string) error
+	ping(ctx context.Context) (string, error)
+	close() error
+	// driver is RedisDriver or MemoryDriver
+	driver() string
+	eval(ctx context.Context, script *Script, keys []string, args []interface{}) (interface{}, error)
+	// pool is used by redsync to create mutex on the store
+	pool() redsyncredis.Pool
+}
+
+type redisStore struct {
+	client redisClient.UniversalClient
+}
+
+func (s *redisStore) get(ctx context.Context, key string) (string, bool, error) {
+	val, err := s.client.Get(ctx, key).Result()
+	if err == redisClient.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return val, true, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	return s.client.Set(ctx, key, value, expiration).Err()
+}
+
+func (s *redisStore) del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+func (s *redisStore) ping(ctx context.Context) (string, error) {
+	return s.client.Ping(ctx).Result()
+}
+
+func (s *redisStore) close() error {
+	return s.client.Close()
+}
+
+func (s *redisStore) driver() string {
+	return RedisDriver
+}
+
+func (s *redisStore) pool() redsyncredis.Pool {
+	return goredis.NewPool(s.client)
+}
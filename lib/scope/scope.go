@@ -2,6 +2,7 @@ package scope
 
 import (
 	"fmt"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -45,6 +46,20 @@ func WhereInScope(key string, value interface{}) Scope {
 	}
 }
 
+// WhereInsensitiveInScope will return a scope with case insensitive WHERE IN condition
+func WhereInsensitiveInScope(key string, value []string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("LOWER(`%s`) IN ?", key), lowerAll(value))
+	}
+}
+
+// WhereInsensitiveNotInScope will return a scope with case insensitive WHERE NOT IN condition
+func WhereInsensitiveNotInScope(key string, value []string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("LOWER(`%s`) NOT IN ?", key), lowerAll(value))
+	}
+}
+
 // WhereIsScope will return a scope with = condition
 func WhereIsScope(key string, value interface{}) Scope {
 	return func(db *gorm.DB) *gorm.DB {
@@ -66,6 +81,13 @@ func WhereLikeScope(key string, value string) Scope {
 	}
 }
 
+// WhereNotLikeScope will return a scope with NOT LIKE condition
+func WhereNotLikeScope(key string, value string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` NOT LIKE ?", key), "%"+value+"%")
+	}
+}
+
 // WhereBetweenScope will return a scope with BETWEEN value1 AND value2 condition
 func WhereBetweenScope(key string, value1 interface{}, value2 interface{}) Scope {
 	return func(db *gorm.DB) *gorm.DB {
@@ -73,6 +95,41 @@ func WhereBetweenScope(key string, value1 interface{}, value2 interface{}) Scope
 	}
 }
 
+// WhereNotBetweenScope will return a scope with NOT BETWEEN value1 AND value2 condition
+func WhereNotBetweenScope(key string, value1 interface{}, value2 interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` NOT BETWEEN ? AND ?", key), value1, value2)
+	}
+}
+
+// WhereGreaterThanScope will return a scope with > condition
+func WhereGreaterThanScope(key string, value interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` > ?", key), value)
+	}
+}
+
+// WhereGreaterThanOrEqualScope will return a scope with >= condition
+func WhereGreaterThanOrEqualScope(key string, value interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` >= ?", key), value)
+	}
+}
+
+// WhereLessThanScope will return a scope with < condition
+func WhereLessThanScope(key string, value interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` < ?", key), value)
+	}
+}
+
+// WhereLessThanOrEqualScope will return a scope with <= condition
+func WhereLessThanOrEqualScope(key string, value interface{}) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%s` <= ?", key), value)
+	}
+}
+
 // WhereIsNull will return a scope with null value for given key
 func WhereIsNullScope(key string) Scope {
 	return func(db *gorm.DB) *gorm.DB {
@@ -87,6 +144,14 @@ func WhereIsNotNullScope(key string) Scope {
 	}
 }
 
+// WhereNoneScope will return a scope that match no record
+// it is used in place of filter with invalid value, so the filter never widen the result
+func WhereNoneScope() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("1 = 0")
+	}
+}
+
 // WhereIsNotNull will return a scope with not null value for given key
 func JoinScope(key string) Scope {
 	return func(db *gorm.DB) *gorm.DB {
@@ -100,3 +165,11 @@ func PreloadScope(key string) Scope {
 		return db.Preload(key)
 	}
 }
+
+func lowerAll(value []string) []string {
+	res := make([]string, len(value))
+	for i, v := range value {
+		res[i] = strings.ToLower(v)
+	}
+	return res
+}
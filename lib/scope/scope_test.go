@@ -33,7 +33,6 @@ func SetupDB() (*gorm.DB, sqlmock.Sqlmock) {
 	mockDb, mock, err := sqlmock.New()
 	if err != nil {
 		panic("setup mock database failed")
-		return nil, nil
 	}
 
 	mock.ExpectQuery("SELECT VERSION()").
@@ -98,7 +97,7 @@ func (suite *TestScopeSuite) TestOrderScope() {
 func (suite *TestScopeSuite) TestWhereNotInScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id NOT IN \\(\\?,\\?,\\?\\)").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` NOT IN \\(\\?,\\?,\\?\\)").
 		WithArgs(2, 3, 4).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -114,7 +113,7 @@ func (suite *TestScopeSuite) TestWhereNotInScope() {
 func (suite *TestScopeSuite) TestWhereInScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id IN \\(\\?,\\?,\\?\\)").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` IN \\(\\?,\\?,\\?\\)").
 		WithArgs(1, 2, 3).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -127,10 +126,26 @@ func (suite *TestScopeSuite) TestWhereInScope() {
 	suite.Equal(1, result)
 }
 
+func (suite *TestScopeSuite) TestWhereInsensitiveInScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE LOWER\\(`status`\\) IN \\(\\?,\\?\\)").
+		WithArgs("paid", "unpaid").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereInsensitiveInScope("status", []string{"Paid", "UNPAID"})).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
 func (suite *TestScopeSuite) TestWhereIsScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id = \\?").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` = \\?").
 		WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -143,10 +158,25 @@ func (suite *TestScopeSuite) TestWhereIsScope() {
 	suite.Equal(1, result)
 }
 
+func (suite *TestScopeSuite) TestWhereNoneScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE 1 = 0").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	var result []int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereNoneScope()).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Empty(result)
+}
+
 func (suite *TestScopeSuite) TestWhereIsNotScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id <> \\?").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` <> \\?").
 		WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -162,7 +192,7 @@ func (suite *TestScopeSuite) TestWhereIsNotScope() {
 func (suite *TestScopeSuite) TestWhereLikeScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE name LIKE \\?").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `name` LIKE \\?").
 		WithArgs("%test%").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -178,7 +208,7 @@ func (suite *TestScopeSuite) TestWhereLikeScope() {
 func (suite *TestScopeSuite) TestWhereBetweenScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id BETWEEN \\? AND \\?").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` BETWEEN \\? AND \\?").
 		WithArgs(1, 2).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -191,10 +221,106 @@ func (suite *TestScopeSuite) TestWhereBetweenScope() {
 	suite.Equal(1, result)
 }
 
+func (suite *TestScopeSuite) TestWhereNotLikeScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `name` NOT LIKE \\?").
+		WithArgs("%test%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereNotLikeScope("name", "test")).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
+func (suite *TestScopeSuite) TestWhereNotBetweenScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` NOT BETWEEN \\? AND \\?").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereNotBetweenScope("id", 1, 2)).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
+func (suite *TestScopeSuite) TestWhereGreaterThanScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `price` > \\?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereGreaterThanScope("price", 10)).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
+func (suite *TestScopeSuite) TestWhereGreaterThanOrEqualScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `price` >= \\?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereGreaterThanOrEqualScope("price", 10)).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
+func (suite *TestScopeSuite) TestWhereLessThanScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `price` < \\?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereLessThanScope("price", 10)).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
+func (suite *TestScopeSuite) TestWhereLessThanOrEqualScope() {
+	mock := suite.mock
+
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `price` <= \\?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var result int
+	err := suite.db.Table("test").
+		Scopes(scope.WhereLessThanOrEqualScope("price", 10)).
+		Pluck("id", &result).Error
+
+	suite.Nil(err)
+	suite.Equal(1, result)
+}
+
 func (suite *TestScopeSuite) TestMultipleScope() {
 	mock := suite.mock
 
-	mock.ExpectQuery("SELECT `id` FROM `test` WHERE id = \\? AND name = \\? ORDER BY id asc LIMIT 1 OFFSET 1").
+	mock.ExpectQuery("SELECT `id` FROM `test` WHERE `id` = \\? AND `name` = \\? ORDER BY id asc LIMIT 1 OFFSET 1").
 		WithArgs(1, "test").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	catalogMutex sync.RWMutex
+	definitions  = make(map[string]Definition)
+)
+
+// Definition is a registered error of the catalog
+type Definition struct {
+	Code        string      `json:"code"`
+	HTTPCode    int         `json:"http_code"`
+	Message     interface{} `json:"message"`
+	Description string      `json:"description"`
+}
+
+func init() {
+	MustRegister(ErrTetapTenangTetapSemangat, "Unexpected error, the detail is only written to the log")
+	MustRegister(ErrBadRequest, "The request can not be parsed")
+	MustRegister(ErrUnauthorized, "The request does not have valid authentication")
+	MustRegister(ErrForbidden, "The authenticated user is not allowed to access the resource")
+	MustRegister(ErrNotFound, "The requested resource does not exist")
+	MustRegister(ErrUnprocessableEntity, "The request is well formed but it is not valid")
+	MustRegister(ErrFailedAuthentication, "The given credentials are not valid")
+	MustRegister(ErrConflict, "The resource already exist, e.g. duplicate unique key")
+	MustRegister(ErrServiceUnavailable, "Temporary failure such as deadlock, the request can be retried")
+	MustRegister(ErrClientClosedRequest, "The client cancel the request before it is completed")
+	MustRegister(ErrGatewayTimeout, "The request is not completed before its deadline")
+}
+
+// Register will add the error to the catalog with its description
+// error with empty or already registered code is rejected
+func Register(c CustomError, description string) error {
+	if c.Code == "" {
+		return fmt.Errorf("errors: can not register error without code: %v", c.Message)
+	}
+
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	if _, ok := definitions[c.Code]; ok {
+		return fmt.Errorf("errors: code %s is already registered", c.Code)
+	}
+
+	definitions[c.Code] = Definition{
+		Code:        c.Code,
+		HTTPCode:    c.HTTPCode,
+		Message:     c.Message,
+		Description: description,
+	}
+	return nil
+}
+
+// MustRegister will register the error and return it, it panics when the error is rejected
+// it is meant to be used on package variable so duplicate code fail on init
+// e.g. var ErrOrderLocked = errors.MustRegister(errors.New("ORDER_LOCKED", 422, "Order is locked"), "Paid order can not be changed")
+func MustRegister(c CustomError, description string) CustomError {
+	if err := Register(c, description); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Lookup will return the registered definition of the code
+func Lookup(code string) (Definition, bool) {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+
+	definition, ok := definitions[code]
+	return definition, ok
+}
+
+// Catalog will return every registered definition sorted by code
+func Catalog() []Definition {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+
+	sorted := make([]Definition, 0, len(definitions))
+	for _, definition := range definitions {
+		sorted = append(sorted, definition)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Code < sorted[j].Code
+	})
+	return sorted
+}
+
+// WriteCatalogJSON will write the catalog as json array for api documentation
+func WriteCatalogJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Catalog())
+}
+
+// WriteCatalogMarkdown will write the catalog as markdown table for api documentation
+func WriteCatalogMarkdown(w io.Writer) error {
+	var builder strings.Builder
+	builder.WriteString("| Code | HTTP Code | Message | Description |\n")
+	builder.WriteString("| --- | --- | --- | --- |\n")
+	for _, definition := range Catalog() {
+		fmt.Fprintf(&builder, "| `%s` | %d | %s | %s |\n",
+			definition.Code,
+			definition.HTTPCode,
+			markdownCell(fmt.Sprint(definition.Message)),
+			markdownCell(definition.Description),
+		)
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+var errOrderLocked = errors.MustRegister(
+	errors.New("ORDER_LOCKED", http.StatusUnprocessableEntity, "Order is locked"),
+	"Paid order can not be changed | refund it instead",
+)
+
+func TestRegister(t *testing.T) {
+	definition, ok := errors.Lookup("ORDER_LOCKED")
+	assert.True(t, ok)
+	assert.Equal(t, errors.Definition{
+		Code:        "ORDER_LOCKED",
+		HTTPCode:    http.StatusUnprocessableEntity,
+		Message:     "Order is locked",
+		Description: "Paid order can not be changed | refund it instead",
+	}, definition)
+
+	assert.Error(t, errors.Register(errors.New("NOT_FOUND", http.StatusNotFound, "Order not exist"), ""))
+	assert.Error(t, errors.Register(errors.New("", http.StatusNotFound, "Order not exist"), ""))
+	assert.Panics(t, func() {
+		errors.MustRegister(errOrderLocked, "")
+	})
+}
+
+func TestWriteCatalog(t *testing.T) {
+	var markdown bytes.Buffer
+	assert.Nil(t, errors.WriteCatalogMarkdown(&markdown))
+	assert.Contains(t, markdown.String(), "| Code | HTTP Code | Message | Description |\n")
+	assert.Contains(t, markdown.String(), "| `ORDER_LOCKED` | 422 | Order is locked | Paid order can not be changed \\| refund it instead |\n")
+
+	var body bytes.Buffer
+	assert.Nil(t, errors.WriteCatalogJSON(&body))
+
+	var definitions []errors.Definition
+	assert.Nil(t, json.Unmarshal(body.Bytes(), &definitions))
+	assert.Equal(t, "BAD_REQUEST", definitions[0].Code)
+}
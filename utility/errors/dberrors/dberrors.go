@@ -0,0 +1,150 @@
+package dberrors
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// mysql server error numbers translated by Translate
+const (
+	mysqlLockWaitTimeout = 1205
+	mysqlDeadlock        = 1213
+	mysqlDuplicateEntry  = 1062
+	mysqlRowIsReferenced = 1451
+	mysqlNoReferencedRow = 1452
+)
+
+const (
+	translatorCallbackName = "dberrors:translate"
+	// translateSettingKey is the gorm setting of the session created by Session
+	translateSettingKey = "dberrors:translate"
+)
+
+var (
+	duplicateKeyRegex = regexp.MustCompile(`for key '([^']+)'`)
+	foreignKeyRegex   = regexp.MustCompile("CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`\\)")
+)
+
+// Translate will convert gorm and mysql driver error into errors.CustomError
+//
+//	gorm.ErrRecordNotFound        -> errors.ErrNotFound
+//	duplicate entry (1062)        -> errors.ErrConflict with the violated key in details
+//	foreign key (1451, 1452)      -> errors.ErrUnprocessableEntity with the constraint in details
+//	deadlock, lock wait timeout   -> errors.ErrServiceUnavailable that is retryable
+//	context.Canceled              -> errors.ErrClientClosedRequest
+//	context.DeadlineExceeded      -> errors.ErrGatewayTimeout
+//
+// the original error is kept as cause, CustomError and unknown error is returned as is
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var customError errors.CustomError
+	if errors.As(err, &customError) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errors.Wrap(errors.ErrNotFound, err, "")
+	case errors.Is(err, context.Canceled):
+		return errors.Wrap(errors.ErrClientClosedRequest, err, "")
+	case errors.Is(err, context.DeadlineExceeded):
+		return errors.Wrap(errors.ErrGatewayTimeout, err, "")
+	case errors.Is(err, mysql.ErrInvalidConn), errors.Is(err, driver.ErrBadConn):
+		return retryable(err)
+	}
+
+	var mysqlError *mysql.MySQLError
+	if !errors.As(err, &mysqlError) {
+		return err
+	}
+
+	switch mysqlError.Number {
+	case mysqlDuplicateEntry:
+		key := submatch(duplicateKeyRegex, mysqlError.Message, 1)
+		return errors.Wrap(errors.ErrConflict, err, key).
+			WithDetails(map[string]interface{}{"key": key})
+	case mysqlRowIsReferenced, mysqlNoReferencedRow:
+		key := submatch(foreignKeyRegex, mysqlError.Message, 2)
+		return errors.Wrap(errors.ErrUnprocessableEntity, err, key).
+			WithDetails(map[string]interface{}{
+				"key":        key,
+				"constraint": submatch(foreignKeyRegex, mysqlError.Message, 1),
+			})
+	case mysqlDeadlock, mysqlLockWaitTimeout:
+		return retryable(err)
+	}
+
+	return err
+}
+
+// Translator is gorm plugin that translate the statement error by Translate
+// so repository can return db.Error directly, e.g. db.Use(dberrors.Translator{})
+//
+// translated db.Error is no longer equal to gorm.ErrRecordNotFound, so existing code that check
+// db.Error == gorm.ErrRecordNotFound stop matching, it must use errors.Is instead.
+// for that reason only statement of session created by Session is translated,
+// set Global to translate every statement of the db once every check use errors.Is
+type Translator struct {
+	Global bool
+}
+
+// Name will return the plugin name
+func (Translator) Name() string {
+	return translatorCallbackName
+}
+
+// Initialize will register the translator after every callback of the db
+func (t Translator) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	translate := func(tx *gorm.DB) {
+		if !t.Global {
+			if enabled, _ := tx.Get(translateSettingKey); enabled != true {
+				return
+			}
+		}
+		tx.Error = Translate(tx.Error)
+	}
+
+	for _, register := range []func(string, func(*gorm.DB)) error{
+		callback.Create().After("*").Register,
+		callback.Query().After("*").Register,
+		callback.Update().After("*").Register,
+		callback.Delete().After("*").Register,
+		callback.Row().After("*").Register,
+		callback.Raw().After("*").Register,
+	} {
+		if err := register(translatorCallbackName, translate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Session will return new session of the db whose statement error is translated by Translator
+// e.g. err := dberrors.Session(db).First(&order, id).Error
+func Session(db *gorm.DB) *gorm.DB {
+	return db.Set(translateSettingKey, true).Session(&gorm.Session{})
+}
+
+func retryable(err error) errors.CustomError {
+	return errors.Wrap(errors.ErrServiceUnavailable, err, "").
+		WithDetails(map[string]interface{}{"retryable": true})
+}
+
+func submatch(regex *regexp.Regexp, s string, index int) string {
+	matches := regex.FindStringSubmatch(s)
+	if len(matches) <= index {
+		return ""
+	}
+	return matches[index]
+}
@@ -0,0 +1,135 @@
+package dberrors_test
+
+import (
+	"context"
+	goerrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/errors/dberrors"
+)
+
+func TestTranslateDatabaseError(t *testing.T) {
+	t.Run("record not found", func(t *testing.T) {
+		err := dberrors.Translate(gorm.ErrRecordNotFound)
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("duplicate entry", func(t *testing.T) {
+		err := dberrors.Translate(&mysql.MySQLError{
+			Number:  1062,
+			Message: "Duplicate entry 'test@mail.com' for key 'users.email'",
+		})
+
+		var customError errors.CustomError
+		assert.True(t, errors.As(err, &customError))
+		assert.Equal(t, http.StatusConflict, customError.HTTPCode)
+		assert.Equal(t, "Data already exist: users.email", customError.Message)
+		assert.Equal(t, "users.email", customError.Details()["key"])
+	})
+
+	t.Run("foreign key", func(t *testing.T) {
+		err := dberrors.Translate(&mysql.MySQLError{
+			Number: 1452,
+			Message: "Cannot add or update a child row: a foreign key constraint fails " +
+				"(`shop`.`orders`, CONSTRAINT `fk_orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))",
+		})
+
+		var customError errors.CustomError
+		assert.True(t, errors.As(err, &customError))
+		assert.True(t, errors.Is(err, errors.ErrUnprocessableEntity))
+		assert.Equal(t, "user_id", customError.Details()["key"])
+		assert.Equal(t, "fk_orders_user", customError.Details()["constraint"])
+	})
+
+	t.Run("deadlock", func(t *testing.T) {
+		err := dberrors.Translate(&mysql.MySQLError{Number: 1213, Message: "Deadlock found"})
+		assert.True(t, errors.Is(err, errors.ErrServiceUnavailable))
+		assert.True(t, errors.IsRetryable(err))
+		assert.False(t, errors.IsRetryable(errors.ErrNotFound))
+	})
+
+	t.Run("context", func(t *testing.T) {
+		canceled := dberrors.Translate(context.Canceled)
+		assert.True(t, errors.Is(canceled, errors.ErrClientClosedRequest))
+		assert.True(t, errors.Is(dberrors.Translate(context.DeadlineExceeded), errors.ErrGatewayTimeout))
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		err := goerrors.New("unknown")
+		assert.Equal(t, err, dberrors.Translate(err))
+		assert.Equal(t, &mysql.MySQLError{Number: 1146}, dberrors.Translate(&mysql.MySQLError{Number: 1146}))
+		assert.Nil(t, dberrors.Translate(nil))
+	})
+}
+
+func TestTranslateStackTrace(t *testing.T) {
+	errors.CaptureStackTrace = true
+	defer func() { errors.CaptureStackTrace = false }()
+
+	// frames of the errors package and its sub packages are skipped
+	translated := dberrors.Translate(gorm.ErrRecordNotFound).(errors.CustomError)
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors/dberrors_test.TestTranslateStackTrace", translated.Origin())
+}
+
+func setupDB(t *testing.T, translator dberrors.Translator) (*gorm.DB, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(mock.NewRows([]string{"version()"}).AddRow("test_version"))
+	db, err := gorm.Open(gormmysql.New(gormmysql.Config{
+		Conn: mockDb,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, db.Use(translator))
+
+	return db, mock
+}
+
+func TestTranslator(t *testing.T) {
+	db, mock := setupDB(t, dberrors.Translator{})
+
+	mock.ExpectQuery("SELECT \\* FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT \\* FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("INSERT INTO `users`").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"})
+
+	// statement outside the session is not translated
+	var user struct{ ID int }
+	err := db.Table("users").First(&user).Error
+	assert.True(t, err == gorm.ErrRecordNotFound)
+
+	session := dberrors.Session(db)
+	err = session.Table("users").First(&user).Error
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	err = session.Table("users").Create(map[string]interface{}{"id": 1}).Error
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+}
+
+func TestGlobalTranslator(t *testing.T) {
+	db, mock := setupDB(t, dberrors.Translator{Global: true})
+
+	mock.ExpectQuery("SELECT \\* FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	var user struct{ ID int }
+	err := db.Table("users").First(&user).Error
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+}
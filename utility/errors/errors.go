@@ -1,6 +1,7 @@
 package errors
 
 import (
+	goerrors "errors"
 	"fmt"
 	"net/http"
 )
@@ -8,49 +9,261 @@ import (
 var (
 	// ErrTetapTenangTetapSemangat custom error on unexpected error
 	ErrTetapTenangTetapSemangat = CustomError{
+		Code:     "INTERNAL_ERROR",
 		Message:  "Tetap Tenang Tetap Semangat",
 		HTTPCode: http.StatusInternalServerError,
 	}
 
 	ErrBadRequest = CustomError{
+		Code:     "BAD_REQUEST",
 		Message:  "Bad Request",
 		HTTPCode: http.StatusBadRequest,
 	}
 
 	ErrUnauthorized = CustomError{
+		Code:     "UNAUTHORIZED",
 		Message:  "Unauthorized",
 		HTTPCode: http.StatusUnauthorized,
 	}
 
 	ErrForbidden = CustomError{
+		Code:     "FORBIDDEN",
 		Message:  "Forbidden",
 		HTTPCode: http.StatusForbidden,
 	}
 
 	ErrNotFound = CustomError{
+		Code:     "NOT_FOUND",
 		Message:  "Record not exist",
 		HTTPCode: http.StatusNotFound,
 	}
 
 	ErrUnprocessableEntity = CustomError{
+		Code:     "UNPROCESSABLE_ENTITY",
 		Message:  "Unprocessable Entity",
 		HTTPCode: http.StatusUnprocessableEntity,
 	}
 
 	ErrFailedAuthentication = CustomError{
+		Code:     "FAILED_AUTHENTICATION",
 		Message:  "Invalid Credentials",
 		HTTPCode: http.StatusUnauthorized,
 	}
+
+	ErrConflict = CustomError{
+		Code:     "CONFLICT",
+		Message:  "Data already exist",
+		HTTPCode: http.StatusConflict,
+	}
+
+	// ErrServiceUnavailable custom error on temporary failure, the request can be retried
+	ErrServiceUnavailable = CustomError{
+		Code:     "SERVICE_UNAVAILABLE",
+		Message:  "Service Unavailable",
+		HTTPCode: http.StatusServiceUnavailable,
+	}
+
+	// ErrClientClosedRequest custom error when the client cancel the request before it is completed
+	ErrClientClosedRequest = CustomError{
+		Code:     "CLIENT_CLOSED_REQUEST",
+		Message:  "Client Closed Request",
+		HTTPCode: StatusClientClosedRequest,
+	}
+
+	ErrGatewayTimeout = CustomError{
+		Code:     "GATEWAY_TIMEOUT",
+		Message:  "Request Timeout",
+		HTTPCode: http.StatusGatewayTimeout,
+	}
 )
 
+// StatusClientClosedRequest is the non standard http code used when the client close the request
+const StatusClientClosedRequest = 499
+
 // CustomError holds data for customized error
+// Code is a stable machine readable code, e.g. ORDER_NOT_FOUND
+// CustomError is comparable, so err == ErrNotFound still match the predefined error
+// but error created by Wrap or With* is not equal to it, use Is to compare it by code
 type CustomError struct {
+	Code     string      `json:"error_code,omitempty"`
 	Message  interface{} `json:"message"`
 	HTTPCode int         `json:"code"`
+	// kind is the code of the error this error is derived from by WithCode
+	kind string
+	// context is the message appended by Wrap
+	context string
+	// extra hold the details, cause and stack, it is a pointer so CustomError stay comparable
+	// it is never modified after it is created, every copy of the error share it
+	extra *extra
+}
+
+type extra struct {
+	details map[string]interface{}
+	cause   error
+	// stack is the program counters captured by WithStack or when CaptureStackTrace is enabled
+	stack []uintptr
+}
+
+// New will create CustomError with code, http code and message
+func New(code string, httpCode int, message interface{}) CustomError {
+	c := CustomError{
+		Code:     code,
+		Message:  message,
+		HTTPCode: httpCode,
+	}
+	if CaptureStackTrace {
+		stack := callers()
+		c = c.withExtra(func(e *extra) { e.stack = stack })
+	}
+	return c
+}
+
+// Wrap will create a copy of base error with cause
+// message is appended to the base message if it is not empty
+// e.g. Wrap(ErrNotFound, err, "order 12") has message "Record not exist: order 12"
+func Wrap(base CustomError, cause error, message string) CustomError {
+	if message != "" {
+		base.Message = fmt.Sprintf("%v: %s", base.Message, message)
+		base.context = message
+	}
+	var stack []uintptr
+	if CaptureStackTrace {
+		stack = callers()
+	}
+	return base.withExtra(func(e *extra) {
+		e.cause = cause
+		if stack != nil {
+			e.stack = stack
+		}
+	})
 }
 
 // Error is a function to convert error to string.
 // It exists to satisfy error interface
 func (c CustomError) Error() string {
+	if cause := c.Unwrap(); cause != nil {
+		return fmt.Sprintf("%v: %v", c.Message, cause)
+	}
 	return fmt.Sprint(c.Message)
 }
+
+// Unwrap will return the cause of the error
+func (c CustomError) Unwrap() error {
+	if c.extra == nil {
+		return nil
+	}
+	return c.extra.cause
+}
+
+// Details will return the structured details set by WithDetails, do not modify the returned map
+func (c CustomError) Details() map[string]interface{} {
+	if c.extra == nil {
+		return nil
+	}
+	return c.extra.details
+}
+
+// Kind will return the code this error is derived from by WithCode, or its own code when it is not derived
+func (c CustomError) Kind() string {
+	if c.kind != "" {
+		return c.kind
+	}
+	return c.Code
+}
+
+// Is will report whether the error match target
+// error with code is matched by its code or the code it is derived from,
+// otherwise by http code and message
+func (c CustomError) Is(target error) bool {
+	t, ok := target.(CustomError)
+	if !ok {
+		return false
+	}
+
+	if c.Code != "" && t.Code != "" {
+		return c.Code == t.Code || c.kind == t.Code
+	}
+	return c.HTTPCode == t.HTTPCode && fmt.Sprint(c.Message) == fmt.Sprint(t.Message)
+}
+
+// WithCode will return a copy of the error with new code
+// the copy still match the original error, e.g. ErrNotFound.WithCode("ORDER_NOT_FOUND") is ErrNotFound
+func (c CustomError) WithCode(code string) CustomError {
+	if c.kind == "" {
+		c.kind = c.Code
+	}
+	c.Code = code
+	return c
+}
+
+// WithMessage will return a copy of the error with new message
+// the message appended by Wrap is replaced as well
+func (c CustomError) WithMessage(message interface{}) CustomError {
+	c.Message = message
+	c.context = ""
+	if CaptureStackTrace && len(c.stack()) == 0 {
+		stack := callers()
+		c = c.withExtra(func(e *extra) { e.stack = stack })
+	}
+	return c
+}
+
+// WithDetails will return a copy of the error with structured details
+func (c CustomError) WithDetails(details map[string]interface{}) CustomError {
+	return c.withExtra(func(e *extra) { e.details = details })
+}
+
+// WithCause will return a copy of the error with cause
+func (c CustomError) WithCause(cause error) CustomError {
+	var stack []uintptr
+	if CaptureStackTrace && len(c.stack()) == 0 {
+		stack = callers()
+	}
+	return c.withExtra(func(e *extra) {
+		e.cause = cause
+		if stack != nil {
+			e.stack = stack
+		}
+	})
+}
+
+// withExtra will return a copy of the error with modified copy of the extra
+func (c CustomError) withExtra(modify func(e *extra)) CustomError {
+	e := extra{}
+	if c.extra != nil {
+		e = *c.extra
+	}
+	modify(&e)
+	if e.details == nil && e.cause == nil && len(e.stack) == 0 {
+		c.extra = nil
+	} else {
+		c.extra = &e
+	}
+	return c
+}
+
+// IsRetryable will report whether the request failed by temporary error and can be retried
+// the error is retryable when its details has "retryable": true, e.g. deadlock translated by dberrors
+func IsRetryable(err error) bool {
+	var customError CustomError
+	if !As(err, &customError) {
+		return false
+	}
+	retryable, _ := customError.Details()["retryable"].(bool)
+	return retryable
+}
+
+// Is is a shortcut of standard errors.Is
+func Is(err error, target error) bool {
+	return goerrors.Is(err, target)
+}
+
+// As is a shortcut of standard errors.As
+func As(err error, target interface{}) bool {
+	return goerrors.As(err, target)
+}
+
+// Unwrap is a shortcut of standard errors.Unwrap
+func Unwrap(err error) error {
+	return goerrors.Unwrap(err)
+}
@@ -0,0 +1,74 @@
+package errors_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestWrap(t *testing.T) {
+	err := errors.Wrap(errors.ErrNotFound, sql.ErrNoRows, "order 12")
+
+	assert.Equal(t, "Record not exist: order 12", err.Message)
+	assert.Equal(t, "Record not exist: order 12: sql: no rows in result set", err.Error())
+	assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.False(t, errors.Is(err, errors.ErrBadRequest))
+
+	wrapped := fmt.Errorf("service: %w", err)
+	var customError errors.CustomError
+	assert.True(t, errors.As(wrapped, &customError))
+	assert.Equal(t, "NOT_FOUND", customError.Code)
+}
+
+func TestWithCode(t *testing.T) {
+	err := errors.ErrNotFound.
+		WithCode("ORDER_NOT_FOUND").
+		WithDetails(map[string]interface{}{"order_id": 12})
+
+	assert.Equal(t, "ORDER_NOT_FOUND", err.Code)
+	assert.Equal(t, "NOT_FOUND", err.Kind())
+	assert.Equal(t, "NOT_FOUND", errors.ErrNotFound.Kind())
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+	assert.True(t, errors.Is(err, errors.New("ORDER_NOT_FOUND", http.StatusNotFound, "Order not exist")))
+	assert.False(t, errors.Is(errors.ErrNotFound, err))
+	assert.Equal(t, "NOT_FOUND", errors.ErrNotFound.Code) // predefined error is not changed
+}
+
+func TestCompare(t *testing.T) {
+	var err error = errors.ErrNotFound
+	assert.True(t, err == errors.ErrNotFound)
+	assert.False(t, err == errors.ErrBadRequest)
+
+	switch err {
+	case errors.ErrNotFound:
+	default:
+		t.Error("predefined error is not matched by switch")
+	}
+
+	// the copy with details or cause is not equal, but it is still matched by Is
+	err = errors.ErrNotFound.WithDetails(map[string]interface{}{"order_id": 12})
+	assert.False(t, err == errors.ErrNotFound)
+	assert.True(t, err != errors.Wrap(errors.ErrNotFound, sql.ErrNoRows, ""))
+	assert.True(t, errors.Is(err, errors.ErrNotFound))
+}
+
+func TestDetails(t *testing.T) {
+	err := errors.ErrNotFound.WithDetails(map[string]interface{}{"order_id": 12})
+	wrapped := errors.Wrap(err, sql.ErrNoRows, "")
+
+	assert.Nil(t, errors.ErrNotFound.Details())
+	assert.Equal(t, map[string]interface{}{"order_id": 12}, wrapped.Details())
+	assert.Nil(t, err.Unwrap()) // copy does not change the original error
+
+	data, jsonErr := json.Marshal(wrapped)
+	assert.Nil(t, jsonErr)
+	assert.JSONEq(t, `{"error_code":"NOT_FOUND","message":"Record not exist","code":404,"details":{"order_id":12}}`, string(data))
+}
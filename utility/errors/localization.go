@@ -0,0 +1,149 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+var (
+	messageCatalog = catalog.NewBuilder(catalog.Fallback(language.English))
+
+	// languageMatcher is rebuilt by SetSupportedLanguages, both is guarded by languagesMutex
+	languagesMutex     sync.RWMutex
+	supportedLanguages = []language.Tag{language.English, language.Indonesian}
+	languageMatcher    = language.NewMatcher(supportedLanguages)
+
+	messageFuncsMutex sync.RWMutex
+	messageFuncs      = make(map[string]MessageFunc)
+)
+
+// MessageFunc build localized message of error with non string message, e.g. validation field errors
+type MessageFunc func(c CustomError, printer *message.Printer) interface{}
+
+// predefinedMessages hold english and indonesian message of every predefined error keyed by code
+var predefinedMessages = map[string][2]string{
+	ErrTetapTenangTetapSemangat.Code: {"Something went wrong, please try again later", "Tetap Tenang Tetap Semangat"},
+	ErrBadRequest.Code:               {"Bad Request", "Permintaan tidak valid"},
+	ErrUnauthorized.Code:             {"Unauthorized", "Anda belum masuk"},
+	ErrForbidden.Code:                {"Forbidden", "Anda tidak memiliki akses"},
+	ErrNotFound.Code:                 {"Record not exist", "Data tidak ditemukan"},
+	ErrUnprocessableEntity.Code:      {"Unprocessable Entity", "Data tidak dapat diproses"},
+	ErrFailedAuthentication.Code:     {"Invalid Credentials", "Kredensial tidak valid"},
+	ErrConflict.Code:                 {"Data already exist", "Data sudah ada"},
+	ErrServiceUnavailable.Code:       {"Service Unavailable", "Layanan sedang tidak tersedia, silakan coba lagi"},
+	ErrClientClosedRequest.Code:      {"Client Closed Request", "Permintaan dibatalkan"},
+	ErrGatewayTimeout.Code:           {"Request Timeout", "Waktu permintaan habis"},
+}
+
+func init() {
+	for code, messages := range predefinedMessages {
+		_ = SetTranslation(language.English, code, messages[0])
+		_ = SetTranslation(language.Indonesian, code, messages[1])
+	}
+}
+
+// SetTranslation will register message of the key in the given language
+// key is the error code for CustomError, other package may register its own key
+func SetTranslation(tag language.Tag, key string, msg string) error {
+	return messageCatalog.SetString(tag, key, msg)
+}
+
+// SupportedLanguages will return a copy of the languages matched against Accept-Language header value,
+// default to english and indonesian
+func SupportedLanguages() []language.Tag {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	return append([]language.Tag(nil), supportedLanguages...)
+}
+
+// SetSupportedLanguages will replace the languages matched against Accept-Language header value
+// the first one is used when nothing match, set it once on application start
+func SetSupportedLanguages(tags ...language.Tag) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("errors: at least one supported language is required")
+	}
+
+	languagesMutex.Lock()
+	defer languagesMutex.Unlock()
+
+	supportedLanguages = append([]language.Tag(nil), tags...)
+	languageMatcher = language.NewMatcher(supportedLanguages)
+	return nil
+}
+
+// RegisterMessageFunc will register MessageFunc used by Localize for error with the code
+func RegisterMessageFunc(code string, fn MessageFunc) {
+	messageFuncsMutex.Lock()
+	defer messageFuncsMutex.Unlock()
+
+	messageFuncs[code] = fn
+}
+
+// MatchLanguage will return the best supported language of Accept-Language header value
+func MatchLanguage(acceptLanguage string) language.Tag {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	_, index := language.MatchStrings(languageMatcher, acceptLanguage)
+	return supportedLanguages[index]
+}
+
+// NewPrinter will return message printer of the best supported language of Accept-Language header value
+func NewPrinter(acceptLanguage string) *message.Printer {
+	return message.NewPrinter(MatchLanguage(acceptLanguage), message.Catalog(messageCatalog))
+}
+
+// Localize will return a copy of the error with message translated to the Accept-Language header value
+// error is returned as is when acceptLanguage is empty, the code has no translation or the message is customized
+func (c CustomError) Localize(acceptLanguage string) CustomError {
+	if acceptLanguage == "" || c.Code == "" {
+		return c
+	}
+
+	printer := NewPrinter(acceptLanguage)
+
+	messageFuncsMutex.RLock()
+	fn, ok := messageFuncs[c.Code]
+	messageFuncsMutex.RUnlock()
+	if ok {
+		c.Message = fn(c, printer)
+		return c
+	}
+
+	// only untouched message is translated, custom message set by WithMessage is kept
+	msg, ok := c.Message.(string)
+	if !ok {
+		return c
+	}
+	if c.context != "" {
+		msg = strings.TrimSuffix(msg, ": "+c.context)
+	}
+
+	if !isTranslation(c.Code, msg) {
+		return c
+	}
+
+	translated := printer.Sprintf(c.Code)
+	if c.context != "" {
+		translated = fmt.Sprintf("%s: %s", translated, c.context)
+	}
+	c.Message = translated
+	return c
+}
+
+// isTranslation report whether msg is the translation of the key in any supported language
+func isTranslation(key string, msg string) bool {
+	for _, tag := range SupportedLanguages() {
+		translated := message.NewPrinter(tag, message.Catalog(messageCatalog)).Sprintf(key)
+		if translated != key && translated == msg {
+			return true
+		}
+	}
+	return false
+}
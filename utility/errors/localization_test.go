@@ -0,0 +1,65 @@
+package errors_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func TestLocalize(t *testing.T) {
+	assert.Equal(t, "Data tidak ditemukan", errors.ErrNotFound.Localize("id-ID,id;q=0.9").Message)
+	assert.Equal(t, "Record not exist", errors.ErrNotFound.Localize("en-US").Message)
+	assert.Equal(t, "Record not exist", errors.ErrNotFound.Localize("").Message)
+	assert.Equal(t, "Record not exist", errors.ErrNotFound.Localize("fr").Message)
+
+	// predefined message in indonesian is translated to english
+	assert.Equal(t, "Something went wrong, please try again later", errors.ErrTetapTenangTetapSemangat.Localize("en").Message)
+
+	wrapped := errors.Wrap(errors.ErrNotFound, sql.ErrNoRows, "order 12").Localize("id")
+	assert.Equal(t, "Data tidak ditemukan: order 12", wrapped.Message)
+	assert.True(t, errors.Is(wrapped, sql.ErrNoRows))
+
+	// customized message is kept
+	custom := errors.ErrNotFound.WithMessage("order 12 not exist").Localize("id")
+	assert.Equal(t, "order 12 not exist", custom.Message)
+}
+
+func TestLocalizeMessageFunc(t *testing.T) {
+	assert.NoError(t, errors.SetTranslation(language.Indonesian, "ORDER_LOCKED", "Pesanan %v terkunci"))
+	errors.RegisterMessageFunc("ORDER_LOCKED", func(c errors.CustomError, printer *message.Printer) interface{} {
+		return printer.Sprintf(c.Code, c.Details()["order_id"])
+	})
+
+	err := errors.ErrUnprocessableEntity.
+		WithCode("ORDER_LOCKED").
+		WithMessage("order 12 is locked").
+		WithDetails(map[string]interface{}{"order_id": 12})
+
+	assert.Equal(t, "Pesanan 12 terkunci", err.Localize("id").Message)
+	assert.Equal(t, language.Indonesian, errors.MatchLanguage("id-ID"))
+	assert.Equal(t, language.English, errors.MatchLanguage("de"))
+}
+
+func TestSetSupportedLanguages(t *testing.T) {
+	defaults := errors.SupportedLanguages()
+	defer func() {
+		assert.Nil(t, errors.SetSupportedLanguages(defaults...))
+	}()
+
+	assert.NotNil(t, errors.SetSupportedLanguages())
+
+	// the matcher is rebuilt with the new languages
+	assert.Nil(t, errors.SetSupportedLanguages(language.Indonesian, language.English))
+	assert.Equal(t, language.Indonesian, errors.MatchLanguage("fr"))
+	assert.Equal(t, language.English, errors.MatchLanguage("en-US"))
+	assert.Equal(t, "Data tidak ditemukan", errors.ErrNotFound.Localize("fr").Message)
+
+	// the returned languages is a copy
+	errors.SupportedLanguages()[0] = language.French
+	assert.Equal(t, language.Indonesian, errors.SupportedLanguages()[0])
+}
@@ -0,0 +1,175 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CaptureStackTrace will make New, Wrap, WithCause and WithMessage capture the stack trace of the caller
+// WithCause and WithMessage keep the stack trace that is already captured
+// it is disabled by default, enable it once on application start
+// WithStack always capture the stack trace, predefined error returned as is has no stack trace,
+// return it with Wrap or WithStack to record where it is returned
+var CaptureStackTrace = false
+
+// maxStackDepth is the maximum number of captured frames
+const maxStackDepth = 32
+
+// packageImportPath is used to skip frames of this package and its sub packages, e.g. dberrors, from the stack trace
+var packageImportPath = packagePath()
+
+// StackFrame is a single frame of the captured stack trace
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// WithStack will return a copy of the error with the stack trace of the caller
+func (c CustomError) WithStack() CustomError {
+	stack := callers()
+	return c.withExtra(func(e *extra) { e.stack = stack })
+}
+
+// WithoutStack will return a copy of the error without stack trace
+// it is used to strip the stack trace from client response
+func (c CustomError) WithoutStack() CustomError {
+	return c.withExtra(func(e *extra) { e.stack = nil })
+}
+
+// StackTrace will return the captured stack trace, frames of this package and its sub packages are skipped
+func (c CustomError) StackTrace() []StackFrame {
+	stack := c.stack()
+	if len(stack) == 0 {
+		return nil
+	}
+
+	stackFrames := make([]StackFrame, 0, len(stack))
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		if !isPackageFrame(frame.Function) {
+			stackFrames = append(stackFrames, StackFrame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return stackFrames
+}
+
+// Origin will return the function that create the error, empty if stack trace is not captured
+func (c CustomError) Origin() string {
+	stackFrames := c.StackTrace()
+	if len(stackFrames) == 0 {
+		return ""
+	}
+	return stackFrames[0].Function
+}
+
+// Format will format the error, %+v print the error with its origin and stack trace
+func (c CustomError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, c.Error())
+		if s.Flag('+') {
+			stackFrames := c.StackTrace()
+			if len(stackFrames) > 0 {
+				_, _ = fmt.Fprintf(s, "\norigin: %s", stackFrames[0].Function)
+			}
+			for _, frame := range stackFrames {
+				_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+		}
+	case 's':
+		_, _ = io.WriteString(s, c.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", c.Error())
+	default:
+		// customError does not have Format method, so it is formatted as plain struct
+		type customError CustomError
+		_, _ = fmt.Fprintf(s, formatDirective(s, verb), customError(c))
+	}
+}
+
+// formatDirective rebuild the directive of the verb with its flags, width and precision
+func formatDirective(s fmt.State, verb rune) string {
+	var directive strings.Builder
+	directive.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			directive.WriteRune(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		directive.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := s.Precision(); ok {
+		directive.WriteByte('.')
+		directive.WriteString(strconv.Itoa(precision))
+	}
+	directive.WriteRune(verb)
+	return directive.String()
+}
+
+// MarshalJSON will marshal the error with its origin and stack trace when it is captured
+// use WithoutStack before writing the error to client
+func (c CustomError) MarshalJSON() ([]byte, error) {
+	type customError CustomError
+	return json.Marshal(struct {
+		customError
+		Details map[string]interface{} `json:"details,omitempty"`
+		Origin  string                 `json:"origin,omitempty"`
+		Stack   []StackFrame           `json:"stack,omitempty"`
+	}{
+		customError: customError(c),
+		Details:     c.Details(),
+		Origin:      c.Origin(),
+		Stack:       c.StackTrace(),
+	})
+}
+
+func (c CustomError) stack() []uintptr {
+	if c.extra == nil {
+		return nil
+	}
+	return c.extra.stack
+}
+
+// callers will capture the program counters of the caller of the exported function
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	// skip runtime.Callers, callers and the exported function
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// isPackageFrame will report whether the function belong to this package or its sub packages, test package is not skipped
+func isPackageFrame(function string) bool {
+	// function is package path followed by the function name, e.g. github.com/PhantomX7/go-core/utility/errors.Wrap
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return false
+	}
+	pkg := function[:slash+1+dot]
+	if pkg == packageImportPath {
+		return true
+	}
+	return strings.HasPrefix(pkg, packageImportPath+"/") && !strings.HasSuffix(pkg, "_test")
+}
+
+func packagePath() string {
+	pc, _, _, _ := runtime.Caller(0)
+	function := runtime.FuncForPC(pc).Name()
+	// function is github.com/PhantomX7/go-core/utility/errors.packagePath
+	return function[:strings.LastIndex(function, ".")]
+}
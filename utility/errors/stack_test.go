@@ -0,0 +1,68 @@
+package errors_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+func findOrder() error {
+	return errors.ErrTetapTenangTetapSemangat.WithStack()
+}
+
+func TestWithStack(t *testing.T) {
+	err := findOrder().(errors.CustomError)
+
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.findOrder", err.Origin())
+	assert.True(t, strings.HasSuffix(err.StackTrace()[0].File, "stack_test.go"))
+	assert.Equal(t, "Tetap Tenang Tetap Semangat", fmt.Sprintf("%v", err))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "origin: github.com/PhantomX7/go-core/utility/errors_test.findOrder")
+
+	body, _ := json.Marshal(err)
+	assert.Contains(t, string(body), `"origin":"github.com/PhantomX7/go-core/utility/errors_test.findOrder"`)
+	assert.Contains(t, string(body), `"stack":[`)
+
+	body, _ = json.Marshal(err.WithoutStack())
+	assert.JSONEq(t, `{"error_code":"INTERNAL_ERROR","message":"Tetap Tenang Tetap Semangat","code":500}`, string(body))
+}
+
+func TestCaptureStackTrace(t *testing.T) {
+	assert.Empty(t, errors.Wrap(errors.ErrNotFound, sql.ErrNoRows, "").StackTrace())
+
+	errors.CaptureStackTrace = true
+	defer func() { errors.CaptureStackTrace = false }()
+
+	err := errors.Wrap(errors.ErrNotFound, sql.ErrNoRows, "order 12")
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.TestCaptureStackTrace", err.Origin())
+
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.TestCaptureStackTrace",
+		errors.New("ORDER_LOCKED", 422, "order is locked").Origin())
+
+	// predefined error get the stack trace when it is returned with cause or message
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.TestCaptureStackTrace",
+		errors.ErrNotFound.WithCause(sql.ErrNoRows).Origin())
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.TestCaptureStackTrace",
+		errors.ErrNotFound.WithMessage("order 12 not exist").Origin())
+
+	// captured stack trace is kept
+	withStack := findOrder().(errors.CustomError)
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.findOrder", withStack.WithCause(sql.ErrNoRows).Origin())
+	assert.Equal(t, "github.com/PhantomX7/go-core/utility/errors_test.findOrder", withStack.WithMessage("failed").Origin())
+}
+
+func TestFormat(t *testing.T) {
+	err := errors.ErrNotFound
+
+	assert.Equal(t, "Record not exist", fmt.Sprintf("%s", err))
+	assert.Equal(t, `"Record not exist"`, fmt.Sprintf("%q", err))
+
+	// other verb fallback to the default struct formatting
+	assert.Contains(t, fmt.Sprintf("%d", err), "(string=NOT_FOUND) %!d(string=Record not exist) 404 ")
+	assert.Contains(t, fmt.Sprintf("%5d", err), "  404")
+}
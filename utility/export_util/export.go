@@ -0,0 +1,193 @@
+package export_util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/PhantomX7/go-core/utility/request_util"
+	"github.com/PhantomX7/go-core/utility/role"
+)
+
+const (
+	CSVFormat    string = "CSV"
+	NDJSONFormat string = "NDJSON"
+
+	defaultBatchSize = 500
+)
+
+// Column is a single csv column
+// Key is the json key of the model field, Formatter is optional and default to FormatValue
+type Column struct {
+	Header    string
+	Key       string
+	Formatter func(value interface{}) string
+}
+
+// Options configure the export output
+// every row is filtered through role.GetDataJSONByRole with Groups, all field is included if Groups is empty
+// if Columns is empty on csv format, all key of the first row will be used sorted by name
+type Options struct {
+	Format    string
+	Columns   []Column
+	BatchSize int
+	Groups    []string
+}
+
+// ContentType will return the http content type of the given format
+func ContentType(format string) string {
+	if format == NDJSONFormat {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Export will stream every row of model matched by the pagination config scopes into writer
+// limit, offset and order of the pagination config is ignored, rows are read in batches ordered by primary key
+// model can be a struct or pointer to struct, e.g. Order{} or &Order{}
+func Export(db *gorm.DB, model interface{}, config request_util.PaginationConfig, writer io.Writer, options Options) error {
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	batch := reflect.New(reflect.SliceOf(modelType))
+
+	var rowWriter func(row map[string]interface{}) error
+	var flush func() error
+	switch options.Format {
+	case NDJSONFormat:
+		encoder := json.NewEncoder(writer)
+		rowWriter = func(row map[string]interface{}) error {
+			return encoder.Encode(row)
+		}
+		flush = func() error {
+			return nil
+		}
+	case CSVFormat:
+		csvWriter := csv.NewWriter(writer)
+		columns := options.Columns
+		if len(columns) > 0 {
+			if err := csvWriter.Write(headers(columns)); err != nil {
+				return err
+			}
+		}
+		rowWriter = func(row map[string]interface{}) error {
+			if len(columns) == 0 {
+				columns = keyColumns(row)
+				if err := csvWriter.Write(headers(columns)); err != nil {
+					return err
+				}
+			}
+			return csvWriter.Write(record(columns, row))
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	default:
+		return fmt.Errorf("export format %s is not supported", options.Format)
+	}
+
+	err := db.Model(model).
+		Scopes(config.Scopes()...).
+		FindInBatches(batch.Interface(), options.BatchSize, func(tx *gorm.DB, _ int) error {
+			rows := batch.Elem()
+			for i := 0; i < rows.Len(); i++ {
+				data, err := role.GetDataJSONByRole(rows.Index(i).Addr().Interface(), options.Groups...)
+				if err != nil {
+					return err
+				}
+
+				row, ok := data.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("export model %s must be a struct", modelType.Name())
+				}
+
+				if err = rowWriter(row); err != nil {
+					return err
+				}
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+			if flusher, ok := writer.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+func headers(columns []Column) []string {
+	res := make([]string, len(columns))
+	for i, column := range columns {
+		res[i] = column.Header
+	}
+	return res
+}
+
+func record(columns []Column, row map[string]interface{}) []string {
+	res := make([]string, len(columns))
+	for i, column := range columns {
+		formatter := column.Formatter
+		if formatter == nil {
+			formatter = FormatValue
+		}
+		res[i] = formatter(row[column.Key])
+	}
+	return res
+}
+
+// keyColumns build columns from all key of the row sorted by name
+func keyColumns(row map[string]interface{}) []Column {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	columns := make([]Column, len(keys))
+	for i, key := range keys {
+		columns[i] = Column{Header: key, Key: key}
+	}
+	return columns
+}
+
+// FormatValue is the default column formatter
+// nil is written as empty string, time as RFC3339 and nested value as json
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,115 @@
+package export_util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/go-core/lib/scope"
+	"github.com/PhantomX7/go-core/utility/export_util"
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+type Order struct {
+	ID     uint   `json:"id" groups:"public,admin"`
+	Status string `json:"status" groups:"public,admin"`
+	Amount int    `json:"amount" groups:"admin"`
+}
+
+type TestExportSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+}
+
+func TestExport(t *testing.T) {
+	suite.Run(t, new(TestExportSuite))
+}
+
+func (suite *TestExportSuite) SetupTest() {
+	mockDb, mock, err := sqlmock.New()
+	if err != nil {
+		panic("setup mock database failed")
+	}
+
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(mock.
+			NewRows([]string{"version()"}).
+			AddRow("test_version"))
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn: mockDb,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+
+	suite.mock = mock
+	suite.db = db
+}
+
+func (suite *TestExportSuite) expectBatches() {
+	suite.mock.ExpectQuery("SELECT \\* FROM `orders` WHERE `status` = \\? ORDER BY `orders`.`id` LIMIT 2").
+		WithArgs("paid").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "amount"}).
+			AddRow(1, "paid", 1000).
+			AddRow(2, "paid", 2000))
+	suite.mock.ExpectQuery("SELECT \\* FROM `orders` WHERE `status` = \\? AND `orders`.`id` > \\? ORDER BY `orders`.`id` LIMIT 2").
+		WithArgs("paid", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "amount"}).
+			AddRow(3, "paid", 3000))
+}
+
+func (suite *TestExportSuite) TestExportCSV() {
+	suite.expectBatches()
+
+	var buffer bytes.Buffer
+	err := export_util.Export(
+		suite.db,
+		Order{},
+		request_util.NewPaginationConfig(1, 1, "id asc", scope.WhereIsScope("status", "paid")),
+		&buffer,
+		export_util.Options{
+			Format:    export_util.CSVFormat,
+			BatchSize: 2,
+			Columns: []export_util.Column{
+				{Header: "ID", Key: "id"},
+				{Header: "Amount", Key: "amount", Formatter: func(value interface{}) string {
+					return "Rp " + export_util.FormatValue(value)
+				}},
+			},
+		},
+	)
+
+	suite.Nil(err)
+	suite.Nil(suite.mock.ExpectationsWereMet())
+	suite.Equal("ID,Amount\n1,Rp 1000\n2,Rp 2000\n3,Rp 3000\n", buffer.String())
+}
+
+func (suite *TestExportSuite) TestExportNDJSON() {
+	suite.expectBatches()
+
+	var buffer bytes.Buffer
+	err := export_util.Export(
+		suite.db,
+		&Order{},
+		request_util.NewPaginationConfig(20, 0, "", scope.WhereIsScope("status", "paid")),
+		&buffer,
+		export_util.Options{
+			Format:    export_util.NDJSONFormat,
+			BatchSize: 2,
+			Groups:    []string{"public"},
+		},
+	)
+
+	suite.Nil(err)
+	suite.Nil(suite.mock.ExpectationsWereMet())
+	suite.Equal(
+		"{\"id\":1,\"status\":\"paid\"}\n{\"id\":2,\"status\":\"paid\"}\n{\"id\":3,\"status\":\"paid\"}\n",
+		buffer.String(),
+	)
+}
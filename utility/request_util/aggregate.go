@@ -0,0 +1,173 @@
+package request_util
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SumAggregate   string = "SUM"
+	CountAggregate string = "COUNT"
+	AvgAggregate   string = "AVG"
+	MinAggregate   string = "MIN"
+	MaxAggregate   string = "MAX"
+)
+
+// Aggregate is a summary computed over the whole filtered set
+// Field can be * for COUNT, GroupBy is optional and will produce value per group
+// e.g. Aggregate{Name: "total_amount", Function: SumAggregate, Field: "amount"}
+// or Aggregate{Name: "count_per_status", Function: CountAggregate, Field: "*", GroupBy: "status"}
+type Aggregate struct {
+	Name     string
+	Function string
+	Field    string
+	GroupBy  string
+}
+
+// AggregateConfig is optional interface of PaginationConfig that carry aggregates
+// Pagination created by this package implement it, e.g. config.(AggregateConfig).AddAggregate(aggregate)
+type AggregateConfig interface {
+	Aggregates() []Aggregate
+	AddAggregate(aggregate Aggregate)
+}
+
+// RunAggregates will run every aggregate of the pagination config over its scopes
+// meta scopes (limit, offset and order) is not applied so the result cover all filtered record
+// db must already point to the model or table, e.g. db.Model(&Order{})
+// grouped aggregate will return map of group value to the aggregate value, null group is keyed by NullValue
+// SUM, COUNT and AVG value is float64, MIN and MAX of integer and float column is float64
+// MIN and MAX of other column is string or time.Time for DATETIME column with parseTime
+// config that does not implement AggregateConfig has no aggregate and return empty result
+func RunAggregates(db *gorm.DB, config PaginationConfig) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+
+	aggregateConfig, ok := config.(AggregateConfig)
+	if !ok {
+		return res, nil
+	}
+
+	for _, aggregate := range aggregateConfig.Aggregates() {
+		selectColumn, err := aggregateColumn(aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := db.Session(&gorm.Session{}).Scopes(config.Scopes()...)
+
+		if aggregate.GroupBy == "" {
+			var value interface{}
+			if err = tx.Select(selectColumn).Row().Scan(&value); err != nil {
+				return nil, err
+			}
+			if res[aggregate.Name], err = aggregateValue(aggregate, value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		rows, err := tx.
+			Select(fmt.Sprintf("`%s`, %s", aggregate.GroupBy, selectColumn)).
+			Group(aggregate.GroupBy).
+			Rows()
+		if err != nil {
+			return nil, err
+		}
+
+		groups, err := scanGroups(rows, aggregate)
+		if err != nil {
+			return nil, err
+		}
+		res[aggregate.Name] = groups
+	}
+
+	return res, nil
+}
+
+// aggregateColumn build the select column of aggregate, function is whitelisted
+func aggregateColumn(aggregate Aggregate) (string, error) {
+	function := strings.ToUpper(aggregate.Function)
+	switch function {
+	case SumAggregate, CountAggregate, AvgAggregate, MinAggregate, MaxAggregate:
+	default:
+		return "", fmt.Errorf("aggregate function %s is not supported", aggregate.Function)
+	}
+
+	if aggregate.Field == "*" {
+		return fmt.Sprintf("%s(*)", function), nil
+	}
+	return fmt.Sprintf("%s(`%s`)", function, aggregate.Field), nil
+}
+
+// scanGroups scan the group value and the aggregate value of every row and close the rows
+func scanGroups(rows *sql.Rows, aggregate Aggregate) (map[string]interface{}, error) {
+	defer rows.Close()
+
+	groups := make(map[string]interface{})
+	for rows.Next() {
+		var key sql.NullString
+		var value interface{}
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+
+		groupKey := NullValue
+		if key.Valid {
+			groupKey = key.String
+		}
+
+		var err error
+		if groups[groupKey], err = aggregateValue(aggregate, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, rows.Err()
+}
+
+// aggregateValue convert the scanned value according to the function
+// return nil when aggregate of empty set is null
+func aggregateValue(aggregate Aggregate, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch strings.ToUpper(aggregate.Function) {
+	case MinAggregate, MaxAggregate:
+		switch v := value.(type) {
+		case int64, float64:
+			return toFloat(v)
+		case []byte:
+			// driver return text, DECIMAL and DATETIME without parseTime column as bytes
+			return string(v), nil
+		}
+		return value, nil
+	default:
+		number, err := toFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate %s: %v", aggregate.Name, err)
+		}
+		return number, nil
+	}
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case []byte:
+		return strconv.ParseFloat(string(v), 64)
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case time.Time:
+		return 0, fmt.Errorf("can not convert time %v to number", v)
+	default:
+		return strconv.ParseFloat(fmt.Sprint(v), 64)
+	}
+}
@@ -0,0 +1,80 @@
+package request_util_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/PhantomX7/go-core/lib/scope"
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+func (suite *TestPaginationConfigSuite) TestRunAggregates() {
+	pagination := request_util.NewPaginationConfig(20, 20, "id desc", scope.WhereIsScope("customer_id", 1))
+	aggregateConfig := pagination.(request_util.AggregateConfig)
+	aggregateConfig.AddAggregate(request_util.Aggregate{
+		Name:     "total_amount",
+		Function: request_util.SumAggregate,
+		Field:    "amount",
+	})
+	aggregateConfig.AddAggregate(request_util.Aggregate{
+		Name:     "count_per_status",
+		Function: request_util.CountAggregate,
+		Field:    "*",
+		GroupBy:  "status",
+	})
+	aggregateConfig.AddAggregate(request_util.Aggregate{
+		Name:     "last_created_at",
+		Function: request_util.MaxAggregate,
+		Field:    "created_at",
+	})
+
+	suite.mock.ExpectQuery("SELECT SUM\\(`amount`\\) FROM `orders` WHERE `customer_id` = \\?$").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"SUM(`amount`)"}).AddRow(3000))
+	suite.mock.ExpectQuery("SELECT `status`, COUNT\\(\\*\\) FROM `orders` WHERE `customer_id` = \\? GROUP BY `status`$").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "COUNT(*)"}).
+			AddRow("paid", 2).
+			AddRow(nil, 1))
+	suite.mock.ExpectQuery("SELECT MAX\\(`created_at`\\) FROM `orders` WHERE `customer_id` = \\?$").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"MAX(`created_at`)"}).AddRow([]byte("2020-01-02 10:00:00")))
+
+	res, err := request_util.RunAggregates(suite.db.Table("orders"), pagination)
+
+	suite.Nil(err)
+	suite.Equal(map[string]interface{}{
+		"total_amount": float64(3000),
+		"count_per_status": map[string]interface{}{
+			"paid": float64(2),
+			"null": float64(1),
+		},
+		"last_created_at": "2020-01-02 10:00:00",
+	}, res)
+
+	suite.Run("with unsupported function", func() {
+		pagination := request_util.NewDefaultPaginationConfig()
+		pagination.(request_util.AggregateConfig).AddAggregate(request_util.Aggregate{Name: "test", Function: "DROP", Field: "id"})
+
+		_, err := request_util.RunAggregates(suite.db.Table("orders"), pagination)
+		suite.NotNil(err)
+	})
+
+	suite.Run("with row error", func() {
+		pagination := request_util.NewDefaultPaginationConfig()
+		pagination.(request_util.AggregateConfig).AddAggregate(request_util.Aggregate{
+			Name:     "count_per_status",
+			Function: request_util.CountAggregate,
+			Field:    "*",
+			GroupBy:  "status",
+		})
+
+		suite.mock.ExpectQuery("SELECT `status`, COUNT\\(\\*\\) FROM `orders` GROUP BY `status`$").
+			WillReturnRows(sqlmock.NewRows([]string{"status", "COUNT(*)"}).
+				AddRow("paid", 2).
+				RowError(0, errors.ErrServiceUnavailable))
+
+		_, err := request_util.RunAggregates(suite.db.Table("orders"), pagination)
+		suite.True(errors.Is(err, errors.ErrServiceUnavailable))
+	})
+}
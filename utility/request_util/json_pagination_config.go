@@ -0,0 +1,369 @@
+package request_util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/now"
+
+	"github.com/PhantomX7/go-core/lib/scope"
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+const (
+	EqualOperator              string = "eq"
+	LikeOperator               string = "like"
+	InOperator                 string = "in"
+	GreaterThanOperator        string = "gt"
+	GreaterThanOrEqualOperator string = "gte"
+	LessThanOperator           string = "lt"
+	LessThanOrEqualOperator    string = "lte"
+	BetweenOperator            string = "between"
+	NotEqualOperator           string = "ne"
+	NotLikeOperator            string = "not_like"
+	NotInOperator              string = "not_in"
+	NotBetweenOperator         string = "not_between"
+	IsNullOperator             string = "null"
+	NotNullOperator            string = "not_null"
+)
+
+// filterOperators hold allowed operators for every filterable type
+var filterOperators = map[string][]string{
+	IdType:               {EqualOperator, InOperator, NotEqualOperator, NotInOperator, IsNullOperator, NotNullOperator},
+	NumberType:           {EqualOperator, GreaterThanOperator, GreaterThanOrEqualOperator, LessThanOperator, LessThanOrEqualOperator, BetweenOperator, NotEqualOperator, NotBetweenOperator, IsNullOperator, NotNullOperator},
+	StringType:           {LikeOperator, EqualOperator, NotLikeOperator, NotEqualOperator, IsNullOperator, NotNullOperator},
+	BoolType:             {EqualOperator, NotEqualOperator, IsNullOperator, NotNullOperator},
+	DateType:             {EqualOperator, GreaterThanOperator, GreaterThanOrEqualOperator, LessThanOperator, LessThanOrEqualOperator, BetweenOperator, NotEqualOperator, NotBetweenOperator, IsNullOperator, NotNullOperator},
+	DatetimeType:         {EqualOperator, GreaterThanOperator, GreaterThanOrEqualOperator, LessThanOperator, LessThanOrEqualOperator, BetweenOperator, NotEqualOperator, NotBetweenOperator, IsNullOperator, NotNullOperator},
+	ExactType:            {EqualOperator, InOperator, NotEqualOperator, NotInOperator, IsNullOperator, NotNullOperator},
+	InsensitiveExactType: {EqualOperator, InOperator, NotEqualOperator, NotInOperator, IsNullOperator, NotNullOperator},
+	EnumType:             {EqualOperator, InOperator, NotEqualOperator, NotInOperator, IsNullOperator, NotNullOperator},
+}
+
+// FilterRequest is the json document accepted by NewJSONPaginationConfig
+type FilterRequest struct {
+	Filters []Filter `json:"filters"`
+	Sort    []string `json:"sort"`
+	Limit   *int     `json:"limit"`
+	Offset  *int     `json:"offset"`
+}
+
+// Filter is a single condition inside FilterRequest
+// value must be an array for in and between operator and is ignored by null operator
+type Filter struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// NewJSONPaginationConfig will create new Pagination from json request body and filterable list
+// it follow the same rule as NewRequestPaginationConfig, filter with field that is not declared
+// in filterable field will be omitted, query map will be nil
+func NewJSONPaginationConfig(body io.Reader, filterable map[string]string) (PaginationConfig, error) {
+	var request FilterRequest
+
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, errors.Wrap(errors.ErrBadRequest, err, "invalid filter request")
+	}
+
+	return NewFilterRequestPaginationConfig(request, filterable)
+}
+
+// NewFilterRequestPaginationConfig will create new Pagination from decoded FilterRequest and filterable list
+func NewFilterRequestPaginationConfig(request FilterRequest, filterable map[string]string) (PaginationConfig, error) {
+	scopes, err := buildFilterScope(request.Filters, filterable)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := map[string][]string{
+		"sort": request.Sort,
+	}
+	if request.Limit != nil {
+		conditions["limit"] = []string{strconv.Itoa(*request.Limit)}
+	}
+	if request.Offset != nil {
+		conditions["offset"] = []string{strconv.Itoa(*request.Offset)}
+	}
+
+	paginationConfig := Pagination{
+		limit:      buildLimit(conditions),
+		offset:     buildOffset(conditions),
+		order:      buildOrder(conditions),
+		queryMap:   nil,
+		scopes:     scopes,
+		metaScopes: make([]scope.Scope, 0),
+	}
+
+	return injectMetaScope(paginationConfig), nil
+}
+
+// FilterRequestSchema will return json schema (draft-07) of FilterRequest document
+// field and op will be restricted to the given filterable list,
+// limit has no maximum because limit above 100 is clamped to 100 instead of rejected
+func FilterRequestSchema(filterable map[string]string) map[string]interface{} {
+	fields := make([]interface{}, 0)
+	for name, filterType := range filterable {
+		filterType, allowed := parseFilterType(filterType)
+		properties := map[string]interface{}{
+			"field": map[string]interface{}{"const": name},
+			"op":    map[string]interface{}{"enum": filterOperators[filterType]},
+		}
+		if filterType == EnumType {
+			properties["value"] = map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"enum": allowed},
+					map[string]interface{}{"type": "array", "items": map[string]interface{}{"enum": allowed}},
+					map[string]interface{}{"type": "null"},
+				},
+			}
+		}
+		fields = append(fields, map[string]interface{}{
+			"properties": properties,
+		})
+	}
+
+	filterItems := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"field", "op"},
+		"properties": map[string]interface{}{
+			"field": map[string]interface{}{"type": "string"},
+			"op":    map[string]interface{}{"type": "string"},
+			"value": map[string]interface{}{
+				"type": []string{"string", "number", "boolean", "array", "null"},
+			},
+		},
+		// value is only optional for null and not_null operator
+		"if": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"op": map[string]interface{}{"enum": []string{IsNullOperator, NotNullOperator}},
+			},
+		},
+		"else": map[string]interface{}{
+			"required": []string{"value"},
+		},
+	}
+	// empty oneOf is not valid json schema
+	if len(fields) > 0 {
+		filterItems["oneOf"] = fields
+	}
+
+	return map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"filters": map[string]interface{}{
+				"type":  "array",
+				"items": filterItems,
+			},
+			"sort": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"description": "limit above 100 is clamped to 100",
+			},
+			"offset": map[string]interface{}{
+				"type":    "integer",
+				"minimum": 0,
+			},
+		},
+	}
+}
+
+// buildFilterScope build all scope given the filters
+func buildFilterScope(filters []Filter, filterable map[string]string) ([]scope.Scope, error) {
+	scopes := make([]scope.Scope, 0)
+
+	for _, filter := range filters {
+		filterType, ok := filterable[filter.Field]
+		if !ok {
+			continue
+		}
+		filterType, allowed := parseFilterType(filterType)
+
+		if !isAllowedOperator(filterType, filter.Op) {
+			return nil, invalidFilterError(filter, "operator is not supported")
+		}
+
+		switch filter.Op {
+		case IsNullOperator:
+			scopes = append(scopes, scope.WhereIsNullScope(filter.Field))
+			continue
+		case NotNullOperator:
+			scopes = append(scopes, scope.WhereIsNotNullScope(filter.Field))
+			continue
+		}
+
+		values := filterValues(filter.Value)
+		switch filter.Op {
+		case InOperator, NotInOperator:
+			if len(values) == 0 {
+				return nil, invalidFilterError(filter, "value must be a non empty array")
+			}
+		case BetweenOperator, NotBetweenOperator:
+			if len(values) != 2 {
+				return nil, invalidFilterError(filter, "value must be an array of 2 element")
+			}
+		default:
+			if len(values) != 1 {
+				return nil, invalidFilterError(filter, "value must be a single value")
+			}
+		}
+
+		if filterType == EnumType {
+			for _, value := range values {
+				if !contains(allowed, value) {
+					return nil, invalidFilterError(filter, fmt.Sprintf(
+						"value %s is not allowed, allowed value: %s", value, strings.Join(allowed, ", "),
+					))
+				}
+			}
+		}
+
+		filterScope, err := buildTypedScope(filter, filterType, values)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, filterScope)
+	}
+
+	return scopes, nil
+}
+
+// buildTypedScope coerce the filter values according to its type and build the scope
+func buildTypedScope(filter Filter, filterType string, values []string) (scope.Scope, error) {
+	name := filter.Field
+
+	switch filterType {
+	case IdType:
+		if filter.Op == NotEqualOperator || filter.Op == NotInOperator {
+			return scope.WhereNotInScope(name, values), nil
+		}
+		return scope.WhereInScope(name, values), nil
+	case StringType:
+		switch filter.Op {
+		case EqualOperator:
+			return scope.WhereIsScope(name, values[0]), nil
+		case NotEqualOperator:
+			return scope.WhereIsNotScope(name, values[0]), nil
+		case NotLikeOperator:
+			return scope.WhereNotLikeScope(name, values[0]), nil
+		}
+		return scope.WhereLikeScope(name, values[0]), nil
+	case ExactType, EnumType:
+		return inScope(name, values, filter.Op == NotEqualOperator || filter.Op == NotInOperator), nil
+	case InsensitiveExactType:
+		if filter.Op == NotEqualOperator || filter.Op == NotInOperator {
+			return scope.WhereInsensitiveNotInScope(name, values), nil
+		}
+		return scope.WhereInsensitiveInScope(name, values), nil
+	case BoolType:
+		boolean, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return nil, invalidFilterError(filter, "value must be a boolean")
+		}
+		return compareScope(filter.Op, name, boolean, nil), nil
+	case NumberType:
+		for _, value := range values {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, invalidFilterError(filter, "value must be a number")
+			}
+		}
+		return compareScope(filter.Op, name, values[0], values[len(values)-1]), nil
+	case DateType:
+		min, err := now.Parse(values[0])
+		if err != nil {
+			return nil, invalidFilterError(filter, "value must be a date")
+		}
+		max, err := now.Parse(values[len(values)-1])
+		if err != nil {
+			return nil, invalidFilterError(filter, "value must be a date")
+		}
+		switch filter.Op {
+		case GreaterThanOperator, LessThanOrEqualOperator:
+			return compareScope(filter.Op, name, now.New(min).EndOfDay(), nil), nil
+		case GreaterThanOrEqualOperator, LessThanOperator:
+			return compareScope(filter.Op, name, now.New(min).BeginningOfDay(), nil), nil
+		default:
+			return betweenScope(
+				name, now.New(min).BeginningOfDay(), now.New(max).EndOfDay(),
+				filter.Op == NotEqualOperator || filter.Op == NotBetweenOperator,
+			), nil
+		}
+	case DatetimeType:
+		min, err := now.Parse(values[0])
+		if err != nil {
+			return nil, invalidFilterError(filter, "value must be a datetime")
+		}
+		max, err := now.Parse(values[len(values)-1])
+		if err != nil {
+			return nil, invalidFilterError(filter, "value must be a datetime")
+		}
+		return compareScope(filter.Op, name, min.UTC(), max.UTC()), nil
+	}
+
+	return nil, invalidFilterError(filter, "filter type is not supported")
+}
+
+// compareScope build comparison scope given the operator
+// value2 is only used by between operator
+func compareScope(op string, name string, value1 interface{}, value2 interface{}) scope.Scope {
+	switch op {
+	case GreaterThanOperator:
+		return scope.WhereGreaterThanScope(name, value1)
+	case GreaterThanOrEqualOperator:
+		return scope.WhereGreaterThanOrEqualScope(name, value1)
+	case LessThanOperator:
+		return scope.WhereLessThanScope(name, value1)
+	case LessThanOrEqualOperator:
+		return scope.WhereLessThanOrEqualScope(name, value1)
+	case BetweenOperator:
+		return scope.WhereBetweenScope(name, value1, value2)
+	case NotBetweenOperator:
+		return scope.WhereNotBetweenScope(name, value1, value2)
+	case NotEqualOperator:
+		return scope.WhereIsNotScope(name, value1)
+	default:
+		return scope.WhereIsScope(name, value1)
+	}
+}
+
+// filterValues convert json value into list of string
+// so it can be coerced the same way as query parameter
+func filterValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			res = append(res, fmt.Sprint(item))
+		}
+		return res
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func isAllowedOperator(filterType string, op string) bool {
+	for _, allowed := range filterOperators[filterType] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidFilterError(filter Filter, reason string) error {
+	return ErrInvalidFilter.
+		WithMessage(fmt.Sprintf("invalid filter %s %s: %s", filter.Field, filter.Op, reason))
+}
@@ -0,0 +1,118 @@
+package request_util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+type TestJSONPaginationConfigSuite struct {
+	suite.Suite
+}
+
+func TestJSONPagination(t *testing.T) {
+	suite.Run(t, new(TestJSONPaginationConfigSuite))
+}
+
+func (suite *TestJSONPaginationConfigSuite) TestNewJSONPaginationConfig() {
+	filterable := map[string]string{
+		"name":      request_util.StringType,
+		"id":        request_util.IdType,
+		"price":     request_util.NumberType,
+		"is_active": request_util.BoolType,
+		"date":      request_util.DateType,
+		"datetime":  request_util.DatetimeType,
+	}
+
+	suite.Run("without filter", func() {
+		pagination, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{}`), filterable)
+
+		suite.Nil(err)
+		suite.Equal(20, pagination.Limit())          // default limit
+		suite.Equal(0, pagination.Offset())          // skip scope
+		suite.Equal("id desc", pagination.Order())   // default order
+		suite.Equal(2, len(pagination.MetaScopes())) // total 2 meta scope
+		suite.Equal(0, len(pagination.Scopes()))
+		suite.Nil(pagination.QueryMap())
+	})
+
+	suite.Run("with limit, offset, sort and lot of filter", func() {
+		pagination, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{
+			"filters": [
+				{"field": "name", "op": "like", "value": "test"},
+				{"field": "id", "op": "in", "value": [1, 2, 3]},
+				{"field": "price", "op": "gte", "value": 10},
+				{"field": "is_active", "op": "eq", "value": true},
+				{"field": "date", "op": "between", "value": ["2017-10-13", "2017-10-14"]},
+				{"field": "datetime", "op": "lt", "value": "2017-10-13 10:00:00"},
+				{"field": "excluded", "op": "eq", "value": "test"}
+			],
+			"sort": ["name asc", "id desc"],
+			"limit": 200,
+			"offset": 1
+		}`), filterable)
+
+		suite.Nil(err)
+		suite.Equal(100, pagination.Limit())                // limit threshold
+		suite.Equal(1, pagination.Offset())                 // add to scope
+		suite.Equal("name asc,id desc", pagination.Order()) // add to scope
+		suite.Equal(3, len(pagination.MetaScopes()))        // total 3 meta scope
+		suite.Equal(6, len(pagination.Scopes()))            // excluded filter is omitted
+	})
+
+	suite.Run("with invalid json", func() {
+		_, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{`), filterable)
+
+		suite.Equal(400, err.(errors.CustomError).HTTPCode)
+	})
+
+	suite.Run("with unsupported operator", func() {
+		_, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{
+			"filters": [{"field": "is_active", "op": "gte", "value": true}]
+		}`), filterable)
+
+		suite.Equal(422, err.(errors.CustomError).HTTPCode)
+	})
+
+	suite.Run("with not allowed enum value", func() {
+		_, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{
+			"filters": [{"field": "status", "op": "in", "value": ["paid", "refunded"]}]
+		}`), map[string]string{
+			"status": request_util.Enum("paid", "unpaid"),
+		})
+
+		suite.Equal(422, err.(errors.CustomError).HTTPCode)
+	})
+
+	suite.Run("with invalid value", func() {
+		_, err := request_util.NewJSONPaginationConfig(strings.NewReader(`{
+			"filters": [{"field": "price", "op": "between", "value": [1, "abc"]}]
+		}`), filterable)
+
+		suite.Equal(422, err.(errors.CustomError).HTTPCode)
+	})
+}
+
+func (suite *TestJSONPaginationConfigSuite) TestFilterRequestSchema() {
+	schema := request_util.FilterRequestSchema(map[string]string{
+		"price": request_util.NumberType,
+	})
+
+	filters := schema["properties"].(map[string]interface{})["filters"].(map[string]interface{})
+	fields := filters["items"].(map[string]interface{})["oneOf"].([]interface{})
+
+	suite.Equal("http://json-schema.org/draft-07/schema#", schema["$schema"])
+	suite.Equal(1, len(fields))
+
+	items := filters["items"].(map[string]interface{})
+	suite.Equal([]string{"field", "op"}, items["required"]) // value is optional for null operator
+	suite.Contains(items["properties"].(map[string]interface{})["value"].(map[string]interface{})["type"], "null")
+
+	schema = request_util.FilterRequestSchema(map[string]string{})
+	filters = schema["properties"].(map[string]interface{})["filters"].(map[string]interface{})
+	suite.NotContains(filters["items"], "oneOf")
+}
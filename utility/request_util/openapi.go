@@ -0,0 +1,201 @@
+package request_util
+
+import (
+	"fmt"
+	"sort"
+)
+
+// queryOperators hold the operator and its negated operator that is generated by query parameter
+// for every filterable type, the negated operator is used by key suffixed with NegationSuffix
+var queryOperators = map[string][2]string{
+	IdType:               {InOperator, NotInOperator},
+	NumberType:           {BetweenOperator, NotBetweenOperator},
+	StringType:           {LikeOperator, NotLikeOperator},
+	BoolType:             {EqualOperator, NotEqualOperator},
+	DateType:             {BetweenOperator, NotBetweenOperator},
+	DatetimeType:         {BetweenOperator, NotBetweenOperator},
+	ExactType:            {InOperator, NotInOperator},
+	InsensitiveExactType: {InOperator, NotInOperator},
+	EnumType:             {InOperator, NotInOperator},
+}
+
+// OpenAPIParameter is an OpenAPI 3 parameter object
+// allowed operators is exposed through x-operators extension
+type OpenAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required"`
+	Style       string        `json:"style,omitempty"`
+	Explode     *bool         `json:"explode,omitempty"`
+	Schema      OpenAPISchema `json:"schema"`
+	Example     interface{}   `json:"example,omitempty"`
+	Operators   []string      `json:"x-operators,omitempty"`
+}
+
+// OpenAPISchema is a subset of OpenAPI 3 schema object used by query parameter
+type OpenAPISchema struct {
+	Type     string         `json:"type"`
+	Format   string         `json:"format,omitempty"`
+	Items    *OpenAPISchema `json:"items,omitempty"`
+	Enum     []string       `json:"enum,omitempty"`
+	MinItems *int           `json:"minItems,omitempty"`
+	MaxItems *int           `json:"maxItems,omitempty"`
+	Minimum  *int           `json:"minimum,omitempty"`
+	Maximum  *int           `json:"maximum,omitempty"`
+	Default  interface{}    `json:"default,omitempty"`
+}
+
+// OpenAPIParameters will generate OpenAPI 3 query parameters for NewRequestPaginationConfig
+// given the filterable and sortable field, parameters are sorted by name, every parameter is followed
+// by its negated parameter suffixed with NegationSuffix, and followed by limit, offset and sort parameter
+func OpenAPIParameters(filterable map[string]string, sortable []string) []OpenAPIParameter {
+	names := make([]string, 0, len(filterable))
+	for name := range filterable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameters := make([]OpenAPIParameter, 0, len(names)*2+3)
+	for _, name := range names {
+		parameter := buildOpenAPIParameter(name, filterable[name])
+		parameters = append(parameters, parameter, negateOpenAPIParameter(parameter, filterable[name]))
+	}
+
+	return append(parameters, paginationOpenAPIParameters(sortable)...)
+}
+
+// buildOpenAPIParameter build parameter of single filterable field
+// the schema follow the query format parsed by buildScope
+// value null is not part of the schema, it is described instead because it is valid for every type
+func buildOpenAPIParameter(name string, filterType string) OpenAPIParameter {
+	filterType, allowed := parseFilterType(filterType)
+	parameter := OpenAPIParameter{
+		Name:  name,
+		In:    "query",
+		Style: "form",
+	}
+	if operators, ok := queryOperators[filterType]; ok {
+		parameter.Operators = []string{operators[0], IsNullOperator}
+	}
+
+	switch filterType {
+	case IdType:
+		parameter.Description = fmt.Sprintf("filter %s by one or more id", name)
+		parameter.Explode = boolPointer(true)
+		parameter.Schema = OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "integer"}}
+		parameter.Example = []int{1, 2}
+	case StringType:
+		parameter.Description = fmt.Sprintf("filter %s containing the value", name)
+		parameter.Schema = OpenAPISchema{Type: "string"}
+		parameter.Example = "keyword"
+	case ExactType, InsensitiveExactType:
+		parameter.Description = fmt.Sprintf("filter %s matching one or more exact value", name)
+		if filterType == InsensitiveExactType {
+			parameter.Description += " ignoring case"
+		}
+		parameter.Explode = boolPointer(true)
+		parameter.Schema = OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "string"}}
+		parameter.Example = []string{"keyword"}
+	case EnumType:
+		parameter.Description = fmt.Sprintf("filter %s by one or more allowed value", name)
+		parameter.Explode = boolPointer(true)
+		parameter.Schema = OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "string", Enum: allowed}}
+		if len(allowed) > 0 {
+			parameter.Example = []string{allowed[0]}
+		}
+	case BoolType:
+		parameter.Description = fmt.Sprintf("filter %s by true or false", name)
+		parameter.Schema = OpenAPISchema{Type: "boolean"}
+		parameter.Example = true
+	case NumberType:
+		parameter.Description = fmt.Sprintf("filter %s between min and max separated by comma", name)
+		parameter.Explode = boolPointer(false)
+		parameter.Schema = rangeOpenAPISchema(OpenAPISchema{Type: "number"})
+		parameter.Example = []int{1000, 2000}
+	case DateType:
+		parameter.Description = fmt.Sprintf("filter %s between start and end date separated by comma", name)
+		parameter.Explode = boolPointer(false)
+		parameter.Schema = rangeOpenAPISchema(OpenAPISchema{Type: "string", Format: "date"})
+		parameter.Example = []string{"2017-10-13", "2017-10-14"}
+	case DatetimeType:
+		parameter.Description = fmt.Sprintf("filter %s between start and end datetime separated by comma", name)
+		parameter.Explode = boolPointer(false)
+		parameter.Schema = rangeOpenAPISchema(OpenAPISchema{Type: "string", Format: "date-time"})
+		parameter.Example = []string{"2017-10-13T00:00:00Z", "2017-10-14T00:00:00Z"}
+	default:
+		parameter.Description = fmt.Sprintf("filter %s", name)
+		parameter.Schema = OpenAPISchema{Type: "string"}
+	}
+
+	parameter.Description += fmt.Sprintf(", value %s filter %s that is null", NullValue, name)
+	return parameter
+}
+
+// negateOpenAPIParameter build the parameter of the key suffixed with NegationSuffix
+// it has the same schema and the negated operators of the parameter
+func negateOpenAPIParameter(parameter OpenAPIParameter, filterType string) OpenAPIParameter {
+	name := parameter.Name
+	parameter.Name = name + NegationSuffix
+	parameter.Description = fmt.Sprintf("exclude %s matched by the %s filter, value %s filter %s that is not null",
+		name, name, NullValue, name)
+
+	filterType, _ = parseFilterType(filterType)
+	if operators, ok := queryOperators[filterType]; ok {
+		parameter.Operators = []string{operators[1], NotNullOperator}
+	}
+	return parameter
+}
+
+// paginationOpenAPIParameters build limit, offset and sort parameter
+// limit has no maximum because limit above 100 is clamped to 100 instead of rejected
+// sort will be restricted to asc and desc of sortable field if any
+func paginationOpenAPIParameters(sortable []string) []OpenAPIParameter {
+	sortSchema := OpenAPISchema{Type: "string"}
+	for _, name := range sortable {
+		sortSchema.Enum = append(sortSchema.Enum, name+" asc", name+" desc")
+	}
+
+	return []OpenAPIParameter{
+		{
+			Name:        "limit",
+			In:          "query",
+			Description: "maximum number of record returned, limit above 100 is clamped to 100",
+			Schema:      OpenAPISchema{Type: "integer", Minimum: intPointer(0), Default: 20},
+			Example:     20,
+		},
+		{
+			Name:        "offset",
+			In:          "query",
+			Description: "number of record skipped",
+			Schema:      OpenAPISchema{Type: "integer", Minimum: intPointer(0), Default: 0},
+			Example:     0,
+		},
+		{
+			Name:        "sort",
+			In:          "query",
+			Description: "order of the record",
+			Style:       "form",
+			Explode:     boolPointer(true),
+			Schema:      OpenAPISchema{Type: "array", Items: &sortSchema, Default: []string{"id desc"}},
+			Example:     []string{"id desc"},
+		},
+	}
+}
+
+func rangeOpenAPISchema(items OpenAPISchema) OpenAPISchema {
+	return OpenAPISchema{
+		Type:     "array",
+		Items:    &items,
+		MinItems: intPointer(2),
+		MaxItems: intPointer(2),
+	}
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func intPointer(value int) *int {
+	return &value
+}
@@ -0,0 +1,47 @@
+package request_util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+func TestOpenAPIParameters(t *testing.T) {
+	parameters := request_util.OpenAPIParameters(
+		map[string]string{
+			"name":       request_util.StringType,
+			"created_at": request_util.DatetimeType,
+			"date":       request_util.DateType,
+		},
+		[]string{"name"},
+	)
+
+	assert.Equal(t, 9, len(parameters)) // 3 filterable, 3 negated and limit, offset, sort
+
+	assert.Equal(t, "created_at", parameters[0].Name)
+	assert.Equal(t, "array", parameters[0].Schema.Type)
+	assert.Equal(t, "date-time", parameters[0].Schema.Items.Format)
+	assert.Equal(t, "created_at!", parameters[1].Name)
+	assert.Equal(t, "date-time", parameters[1].Schema.Items.Format)
+	assert.Equal(t, []string{request_util.NotBetweenOperator, request_util.NotNullOperator}, parameters[1].Operators)
+
+	assert.Equal(t, "date", parameters[2].Name)
+	assert.Equal(t, "date", parameters[2].Schema.Items.Format)
+
+	assert.Equal(t, "name", parameters[4].Name)
+	assert.Equal(t, "string", parameters[4].Schema.Type)
+	assert.Equal(t, []string{request_util.LikeOperator, request_util.IsNullOperator}, parameters[4].Operators)
+	assert.Contains(t, parameters[4].Description, "value null filter name that is null")
+	assert.Equal(t, "name!", parameters[5].Name)
+	assert.Equal(t, []string{request_util.NotLikeOperator, request_util.NotNullOperator}, parameters[5].Operators)
+	assert.Contains(t, parameters[5].Description, "value null filter name that is not null")
+
+	// limit above 100 is clamped, so it is not rejected by the schema
+	assert.Equal(t, "limit", parameters[6].Name)
+	assert.Nil(t, parameters[6].Schema.Maximum)
+
+	assert.Equal(t, "sort", parameters[8].Name)
+	assert.Equal(t, []string{"name asc", "name desc"}, parameters[8].Schema.Items.Enum)
+}
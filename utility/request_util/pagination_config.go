@@ -1,10 +1,14 @@
 package request_util
 
 import (
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PhantomX7/go-core/lib/scope"
+	"github.com/PhantomX7/go-core/utility/errors"
 	"github.com/jinzhu/now"
 )
 
@@ -15,6 +19,36 @@ const (
 	BoolType     string = "BOOL"
 	DateType     string = "DATE"
 	DatetimeType string = "DATETIME"
+	// ExactType match the whole string instead of LIKE
+	ExactType string = "EXACT"
+	// InsensitiveExactType match the whole string ignoring the case
+	InsensitiveExactType string = "IEXACT"
+	// EnumType match one of the allowed values, declare it with Enum
+	EnumType string = "ENUM"
+)
+
+// Enum will return ENUM filter type with the allowed values
+// e.g. "status": Enum("paid", "unpaid", "cancelled")
+// the values are stored as json array after the type, so they can contain any character
+func Enum(values ...string) string {
+	if values == nil {
+		values = []string{}
+	}
+	encoded, _ := json.Marshal(values)
+	return EnumType + ":" + string(encoded)
+}
+
+const (
+	// NullValue is the query value to filter null field, e.g. deleted_by=null
+	NullValue string = "null"
+	// NegationSuffix is appended to the query key to negate the filter, e.g. status!=cancelled
+	NegationSuffix string = "!"
+)
+
+// ErrInvalidFilter is returned when the filter value, field or operator is not allowed
+var ErrInvalidFilter = errors.MustRegister(
+	errors.ErrUnprocessableEntity.WithCode("INVALID_FILTER").WithMessage("Invalid filter"),
+	"The filter field, operator or value is not allowed",
 )
 
 // PaginationConfig is interface for all paginated query or any custom query
@@ -36,6 +70,7 @@ type Pagination struct {
 	queryMap   map[string][]string
 	scopes     []scope.Scope
 	metaScopes []scope.Scope
+	aggregates []Aggregate
 }
 
 // AddScope will add new scope to existing scope
@@ -43,6 +78,16 @@ func (p *Pagination) AddScope(scope scope.Scope) {
 	p.scopes = append(p.scopes, scope)
 }
 
+// AddAggregate will add new aggregate that is run over the scopes by RunAggregates
+func (p *Pagination) AddAggregate(aggregate Aggregate) {
+	p.aggregates = append(p.aggregates, aggregate)
+}
+
+// Aggregates will return all aggregate in current pagination
+func (p *Pagination) Aggregates() []Aggregate {
+	return p.aggregates
+}
+
 // Limit will return current limit of pagination
 func (p *Pagination) Limit() (res int) {
 	return p.limit
@@ -96,13 +141,34 @@ func NewPaginationConfig(limit int, offset int, order string, scopes ...scope.Sc
 // NewRequestPaginationConfig will create new Pagination with request condition and filterable list
 // all resulted scope come from filterable field with conditions field data
 // if any conditions field that is not declared in filterable field will be omitted
+// value that is not allowed by ENUM field will be dropped, the field match nothing when no value is left
+// range of NUMBER, DATE and DATETIME field that can not be parsed will match nothing
+// invalid filter never return error here, the query silently return empty result through scope.WhereNoneScope,
+// use NewValidatedRequestPaginationConfig to reject the request with ErrInvalidFilter instead
 func NewRequestPaginationConfig(conditions map[string][]string, filterable map[string]string) PaginationConfig {
+	scopes, _ := buildScope(conditions, filterable)
+	return newRequestPagination(conditions, scopes)
+}
+
+// NewValidatedRequestPaginationConfig works like NewRequestPaginationConfig
+// but will return nil and ErrInvalidFilter if ENUM field contains value that is not allowed
+// or range of NUMBER, DATE and DATETIME field can not be parsed,
+// it is the only constructor that return ErrInvalidFilter, NewRequestPaginationConfig match nothing instead
+func NewValidatedRequestPaginationConfig(conditions map[string][]string, filterable map[string]string) (PaginationConfig, error) {
+	scopes, err := buildScope(conditions, filterable)
+	if err != nil {
+		return nil, err
+	}
+	return newRequestPagination(conditions, scopes), nil
+}
+
+func newRequestPagination(conditions map[string][]string, scopes []scope.Scope) PaginationConfig {
 	paginationConfig := Pagination{
 		limit:      buildLimit(conditions),
 		offset:     buildOffset(conditions),
 		order:      buildOrder(conditions),
 		queryMap:   conditions,
-		scopes:     buildScope(conditions, filterable),
+		scopes:     scopes,
 		metaScopes: make([]scope.Scope, 0),
 	}
 
@@ -111,7 +177,7 @@ func NewRequestPaginationConfig(conditions map[string][]string, filterable map[s
 
 // NewDefaultPaginationConfig will create a default Pagination with zero scope and 20 limit
 func NewDefaultPaginationConfig() PaginationConfig {
-	return NewPaginationConfig(20, 0, "", nil)
+	return NewPaginationConfig(20, 0, "")
 }
 
 // BuildLimit build the limit with 100 threshold given the conditions
@@ -146,44 +212,211 @@ func buildOrder(conditions map[string][]string) string {
 }
 
 // BuildOrder build all scope given the conditions
-func buildScope(conditions map[string][]string, filterable map[string]string) []scope.Scope {
+// value null will produce IS NULL condition, key suffixed with NegationSuffix
+// (e.g. status!=cancelled) will produce the negated condition of its type
+// filter with invalid value is replaced by scope that match nothing, the first error will be returned alongside
+func buildScope(conditions map[string][]string, filterable map[string]string) ([]scope.Scope, error) {
+	var err error
 	scopes := make([]scope.Scope, 0)
 
 	for name, value := range filterable {
-		if len(conditions[name]) > 0 {
-			switch value {
-			case IdType:
-				scopes = append(scopes, scope.WhereInScope(name, conditions[name]))
-			case StringType:
-				scopes = append(scopes, scope.WhereLikeScope(name, conditions[name][0]))
-			case BoolType:
-				boolean := false
-				if conditions[name][0] == "true" {
-					boolean = true
-				}
-				scopes = append(scopes, scope.WhereIsScope(name, boolean))
-			case NumberType:
-				minmax := strings.Split(conditions[name][0], ",")
-				scopes = append(scopes, scope.WhereBetweenScope(name, minmax[0], minmax[1]))
-			case DateType:
-				minmax := strings.Split(conditions[name][0], ",")
-				min, _ := now.Parse(minmax[0])
-				max, _ := now.Parse(minmax[1])
-				scopes = append(scopes, scope.WhereBetweenScope(
-					name, now.New(min).BeginningOfDay(), now.New(max).EndOfDay(),
-				))
-			case DatetimeType:
-				minmax := strings.Split(conditions[name][0], ",")
-				min, _ := now.Parse(minmax[0])
-				max, _ := now.Parse(minmax[1])
-				scopes = append(scopes, scope.WhereBetweenScope(
-					name, min.UTC(), max.UTC(),
-				))
+		for _, negate := range []bool{false, true} {
+			key := name
+			if negate {
+				key = name + NegationSuffix
+			}
+
+			filterScope, filterErr := buildConditionScope(name, value, conditions[key], negate)
+			if filterErr != nil && err == nil {
+				err = filterErr
+			}
+			if filterScope != nil {
+				scopes = append(scopes, filterScope)
+			}
+		}
+	}
+
+	return scopes, err
+}
+
+// buildConditionScope build scope of single field given the filter type and query value
+// will return nil if there is no value or the filter type is not supported
+func buildConditionScope(name string, filterType string, values []string, negate bool) (scope.Scope, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	if values[0] == NullValue {
+		if negate {
+			return scope.WhereIsNotNullScope(name), nil
+		}
+		return scope.WhereIsNullScope(name), nil
+	}
+
+	filterType, allowed := parseFilterType(filterType)
+	if filterType == EnumType {
+		return buildEnumScope(name, allowed, values, negate)
+	}
+
+	filterScope, err := buildTypedConditionScope(name, filterType, values, negate)
+	if err != nil {
+		return scope.WhereNoneScope(), err
+	}
+	return filterScope, nil
+}
+
+// buildTypedConditionScope build scope of single field given the parsed filter type
+func buildTypedConditionScope(name string, filterType string, values []string, negate bool) (scope.Scope, error) {
+	switch filterType {
+	case IdType:
+		if negate {
+			return scope.WhereNotInScope(name, values), nil
+		}
+		return scope.WhereInScope(name, values), nil
+	case StringType:
+		if negate {
+			return scope.WhereNotLikeScope(name, values[0]), nil
+		}
+		return scope.WhereLikeScope(name, values[0]), nil
+	case ExactType, EnumType:
+		return inScope(name, values, negate), nil
+	case InsensitiveExactType:
+		if negate {
+			return scope.WhereInsensitiveNotInScope(name, values), nil
+		}
+		return scope.WhereInsensitiveInScope(name, values), nil
+	case BoolType:
+		boolean := false
+		if values[0] == "true" {
+			boolean = true
+		}
+		if negate {
+			return scope.WhereIsNotScope(name, boolean), nil
+		}
+		return scope.WhereIsScope(name, boolean), nil
+	case NumberType:
+		min, max, err := splitRange(name, values[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range []string{min, max} {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, invalidRangeError(name, values[0], "value must be a number")
 			}
 		}
+		return betweenScope(name, min, max, negate), nil
+	case DateType, DatetimeType:
+		min, max, err := parseTimeRange(name, values[0])
+		if err != nil {
+			return nil, err
+		}
+		if filterType == DateType {
+			return betweenScope(name, now.New(min).BeginningOfDay(), now.New(max).EndOfDay(), negate), nil
+		}
+		return betweenScope(name, min.UTC(), max.UTC(), negate), nil
+	}
+
+	return nil, nil
+}
+
+// buildEnumScope drop the value that is not allowed and return error of the first one
+// value that is not allowed can not match any record, so it is dropped from = and IN condition
+// and the condition match nothing when no value is left, negated condition is omitted instead
+func buildEnumScope(name string, allowed []string, values []string, negate bool) (scope.Scope, error) {
+	var err error
+	allowedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		if contains(allowed, value) {
+			allowedValues = append(allowedValues, value)
+		} else if err == nil {
+			err = ErrInvalidFilter.
+				WithMessage(fmt.Sprintf("invalid value %s for %s, allowed value: %s", value, name, strings.Join(allowed, ", ")))
+		}
+	}
+
+	if len(allowedValues) == 0 {
+		if negate {
+			return nil, err
+		}
+		return scope.WhereNoneScope(), err
+	}
+	return inScope(name, allowedValues, negate), err
+}
+
+// splitRange split range value of NUMBER, DATE and DATETIME field, e.g. 1000,2000
+func splitRange(name string, value string) (string, string, error) {
+	minmax := strings.Split(value, ",")
+	if len(minmax) != 2 {
+		return "", "", invalidRangeError(name, value, "value must be a range of 2 comma separated value")
+	}
+	return minmax[0], minmax[1], nil
+}
+
+func parseTimeRange(name string, value string) (time.Time, time.Time, error) {
+	minValue, maxValue, err := splitRange(name, value)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	min, err := now.Parse(minValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, invalidRangeError(name, value, "value must be a date")
+	}
+	max, err := now.Parse(maxValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, invalidRangeError(name, value, "value must be a date")
+	}
+	return min, max, nil
+}
+
+func invalidRangeError(name string, value string, reason string) error {
+	return ErrInvalidFilter.WithMessage(fmt.Sprintf("invalid value %s for %s, %s", value, name, reason))
+}
+
+// parseFilterType split the filter type and its parameter
+// e.g. ENUM:["paid","unpaid"] will return ENUM and [paid unpaid]
+func parseFilterType(filterType string) (string, []string) {
+	parts := strings.SplitN(filterType, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	var allowed []string
+	if err := json.Unmarshal([]byte(parts[1]), &allowed); err != nil {
+		return parts[0], nil
+	}
+	return parts[0], allowed
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// inScope build = condition for single value and IN condition for multiple values
+func inScope(name string, values []string, negate bool) scope.Scope {
+	if len(values) == 1 {
+		if negate {
+			return scope.WhereIsNotScope(name, values[0])
+		}
+		return scope.WhereIsScope(name, values[0])
 	}
 
-	return scopes
+	if negate {
+		return scope.WhereNotInScope(name, values)
+	}
+	return scope.WhereInScope(name, values)
+}
+
+func betweenScope(name string, min interface{}, max interface{}, negate bool) scope.Scope {
+	if negate {
+		return scope.WhereNotBetweenScope(name, min, max)
+	}
+	return scope.WhereBetweenScope(name, min, max)
 }
 
 // OverrideKey will override condition key with desired key
@@ -2,9 +2,10 @@ package request_util_test
 
 import (
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/stretchr/testify/suite"
 	"github.com/PhantomX7/go-core/lib/scope"
+	"github.com/PhantomX7/go-core/utility/errors"
 	"github.com/PhantomX7/go-core/utility/request_util"
+	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -32,7 +33,6 @@ func SetupDB() (*gorm.DB, sqlmock.Sqlmock) {
 	mockDb, mock, err := sqlmock.New()
 	if err != nil {
 		panic("setup mock database failed")
-		return nil, nil
 	}
 
 	mock.ExpectQuery("SELECT VERSION()").
@@ -279,4 +279,202 @@ func (suite *TestPaginationConfigSuite) TestNewRequestPaginationConfig() {
 		suite.Equal(6, len(pagination.Scopes()))     // 6 extra scope from query
 		suite.NotNil(pagination.QueryMap())
 	})
+
+	suite.Run("with null and negated query", func() {
+		pagination := request_util.NewRequestPaginationConfig(
+			map[string][]string{
+				"deleted_by":  {"null"},
+				"updated_by":  {"1"},
+				"updated_by!": {"null"},
+				"status!":     {"cancelled"},
+				"id!":         {"1", "2"},
+				"excluded!":   {"test"},
+			},
+			map[string]string{
+				"deleted_by": request_util.IdType,
+				"updated_by": request_util.IdType,
+				"status":     request_util.StringType,
+				"id":         request_util.IdType,
+			},
+		)
+
+		suite.Equal(5, len(pagination.Scopes())) // excluded negation is omitted
+
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE `status` NOT LIKE \\?").
+			WithArgs("%cancelled%").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		var result int
+		err := suite.db.Table("test").
+			Scopes(request_util.NewRequestPaginationConfig(
+				map[string][]string{"status!": {"cancelled"}},
+				map[string]string{"status": request_util.StringType},
+			).Scopes()...).
+			Pluck("id", &result).Error
+
+		suite.Nil(err)
+		suite.Equal(1, result)
+	})
+
+	suite.Run("with invalid range query", func() {
+		for key, value := range map[string]string{
+			"price!":   "10",
+			"price":    "10,abc",
+			"date":     "2020-01-01",
+			"datetime": "2020-01-01,invalid",
+		} {
+			conditions := map[string][]string{key: {value}}
+			filterable := map[string]string{
+				"price":    request_util.NumberType,
+				"date":     request_util.DateType,
+				"datetime": request_util.DatetimeType,
+			}
+
+			_, err := request_util.NewValidatedRequestPaginationConfig(conditions, filterable)
+			suite.True(errors.Is(err, request_util.ErrInvalidFilter), key)
+
+			pagination := request_util.NewRequestPaginationConfig(conditions, filterable)
+			suite.Equal(1, len(pagination.Scopes()), key)
+		}
+
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE 1 = 0").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var result []int
+		err := suite.db.Table("test").
+			Scopes(request_util.NewRequestPaginationConfig(
+				map[string][]string{"price!": {"10"}},
+				map[string]string{"price": request_util.NumberType},
+			).Scopes()...).
+			Pluck("id", &result).Error
+
+		suite.Nil(err)
+		suite.Empty(result) // invalid range match nothing
+	})
+
+	suite.Run("with invalid filter on unvalidated config", func() {
+		conditions := map[string][]string{
+			"status": {"refunded"},
+			"limit":  {"10"},
+		}
+		filterable := map[string]string{
+			"status": request_util.Enum("paid", "unpaid"),
+		}
+
+		_, err := request_util.NewValidatedRequestPaginationConfig(conditions, filterable)
+		suite.True(errors.Is(err, request_util.ErrInvalidFilter))
+
+		// only the validated config return the error, the unvalidated config return empty result
+		pagination := request_util.NewRequestPaginationConfig(conditions, filterable)
+		suite.Equal(10, pagination.Limit())
+
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE 1 = 0").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var result []int
+		err = suite.db.Table("test").
+			Scopes(pagination.Scopes()...).
+			Pluck("id", &result).Error
+
+		suite.Nil(err)
+		suite.Empty(result)
+		suite.Nil(suite.mock.ExpectationsWereMet())
+	})
+
+	suite.Run("with exact and enum query", func() {
+		pagination, err := request_util.NewValidatedRequestPaginationConfig(
+			map[string][]string{
+				"code":    {"INV-1"},
+				"email":   {"Test@Mail.com", "other@mail.com"},
+				"status":  {"paid", "unpaid"},
+				"status!": {"cancelled"},
+			},
+			map[string]string{
+				"code":   request_util.ExactType,
+				"email":  request_util.InsensitiveExactType,
+				"status": request_util.Enum("paid", "unpaid", "cancelled"),
+			},
+		)
+
+		suite.Nil(err)
+		suite.Equal(4, len(pagination.Scopes()))
+
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE `status` IN \\(\\?,\\?\\)").
+			WithArgs("paid", "unpaid").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		var result int
+		err = suite.db.Table("test").
+			Scopes(request_util.NewRequestPaginationConfig(
+				map[string][]string{"status": {"paid", "unpaid"}},
+				map[string]string{"status": request_util.Enum("paid", "unpaid", "cancelled")},
+			).Scopes()...).
+			Pluck("id", &result).Error
+
+		suite.Nil(err)
+		suite.Equal(1, result)
+	})
+
+	suite.Run("with not allowed enum value", func() {
+		conditions := map[string][]string{
+			"status": {"paid", "refunded"},
+		}
+		filterable := map[string]string{
+			"status": request_util.Enum("paid", "unpaid", "cancelled"),
+		}
+
+		pagination, err := request_util.NewValidatedRequestPaginationConfig(conditions, filterable)
+		suite.Nil(pagination)
+		suite.Equal(422, err.(errors.CustomError).HTTPCode)
+
+		// not allowed value is dropped
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE `status` = \\?").
+			WithArgs("paid").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		var result int
+		err = suite.db.Table("test").
+			Scopes(request_util.NewRequestPaginationConfig(conditions, filterable).Scopes()...).
+			Pluck("id", &result).Error
+
+		suite.Nil(err)
+		suite.Equal(1, result)
+
+		// no allowed value left match nothing, negated condition is omitted
+		pagination = request_util.NewRequestPaginationConfig(
+			map[string][]string{"status": {"refunded"}, "status!": {"refunded"}},
+			filterable,
+		)
+		suite.Equal(1, len(pagination.Scopes()))
+
+		suite.mock.ExpectQuery("SELECT `id` FROM `test` WHERE 1 = 0").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var results []int
+		err = suite.db.Table("test").
+			Scopes(pagination.Scopes()...).
+			Pluck("id", &results).Error
+
+		suite.Nil(err)
+		suite.Empty(results)
+	})
+
+	suite.Run("with enum value containing separator", func() {
+		filterable := map[string]string{
+			"category": request_util.Enum("a,b", "c:d"),
+		}
+
+		pagination, err := request_util.NewValidatedRequestPaginationConfig(
+			map[string][]string{"category": {"a,b", "c:d"}},
+			filterable,
+		)
+		suite.Nil(err)
+		suite.Equal(1, len(pagination.Scopes()))
+
+		_, err = request_util.NewValidatedRequestPaginationConfig(
+			map[string][]string{"category": {"a"}},
+			filterable,
+		)
+		suite.True(errors.Is(err, request_util.ErrInvalidFilter))
+	})
 }
@@ -0,0 +1,119 @@
+package response_util
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+// StrongETag will return strong ETag of the serialised body
+func StrongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WeakETag will return weak ETag from the latest modification time and total record
+func WeakETag(lastModified time.Time, total int64) string {
+	return fmt.Sprintf(`W/"%d-%d"`, lastModified.UTC().UnixNano(), total)
+}
+
+// LastModified will return max of column and total record over the pagination config scopes
+// meta scopes is not applied so any change in the filtered set is detected
+// db must already point to the model or table, e.g. db.Model(&Order{}), and connected with parseTime=true
+func LastModified(db *gorm.DB, config request_util.PaginationConfig, column string) (time.Time, int64, error) {
+	var lastModified sql.NullTime
+	var total int64
+
+	err := db.Scopes(config.Scopes()...).
+		Select(fmt.Sprintf("MAX(`%s`), COUNT(*)", column)).
+		Row().
+		Scan(&lastModified, &total)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return lastModified.Time, total, nil
+}
+
+// NotModified will evaluate If-None-Match and If-Modified-Since of GET and HEAD request
+// If-Modified-Since is ignored when If-None-Match is present, zero lastModified is never evaluated
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakMatch(candidate, etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		// http date has second precision
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// SetConditionalHeaders will set ETag and Last-Modified header, empty etag and zero lastModified are skipped
+func SetConditionalHeaders(w http.ResponseWriter, etag string, lastModified time.Time) {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// WriteNotModified will write 304 Not Modified with the validator headers
+func WriteNotModified(w http.ResponseWriter, etag string, lastModified time.Time) {
+	SetConditionalHeaders(w, etag, lastModified)
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// WriteConditionalJSON will write body as json with strong ETag computed from the serialised body
+// 304 Not Modified will be written instead if the request ETag match
+func WriteConditionalJSON(w http.ResponseWriter, r *http.Request, statusCode int, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	etag := StrongETag(b)
+	if NotModified(r, etag, time.Time{}) {
+		WriteNotModified(w, etag, time.Time{})
+		return nil
+	}
+
+	SetConditionalHeaders(w, etag, time.Time{})
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(statusCode)
+	_, err = w.Write(b)
+	return err
+}
+
+// weakMatch compare two ETag using weak comparison
+func weakMatch(a string, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
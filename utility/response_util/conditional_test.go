@@ -0,0 +1,60 @@
+package response_util_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+func TestWriteConditionalJSON(t *testing.T) {
+	body := response_util.IndexResponse{Data: []int{1, 2}}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	assert.Nil(t, response_util.WriteConditionalJSON(w, r, http.StatusOK, body))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	t.Run("with matching etag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		r.Header.Set("If-None-Match", `"other", W/`+etag)
+
+		assert.Nil(t, response_util.WriteConditionalJSON(w, r, http.StatusOK, body))
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, 0, w.Body.Len())
+	})
+
+	t.Run("with changed body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		r.Header.Set("If-None-Match", etag)
+
+		assert.Nil(t, response_util.WriteConditionalJSON(w, r, http.StatusOK, response_util.IndexResponse{}))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2020, 12, 1, 10, 0, 0, 500, time.UTC)
+	etag := response_util.WeakETag(lastModified, 10)
+
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.Header.Set("If-Modified-Since", lastModified.Format(http.TimeFormat))
+	assert.True(t, response_util.NotModified(r, etag, lastModified))
+	assert.False(t, response_util.NotModified(r, etag, lastModified.Add(time.Second)))
+
+	r.Header.Set("If-None-Match", response_util.WeakETag(lastModified, 11))
+	assert.False(t, response_util.NotModified(r, etag, lastModified)) // If-None-Match take precedence
+
+	r = httptest.NewRequest(http.MethodPost, "/orders", nil)
+	r.Header.Set("If-None-Match", etag)
+	assert.False(t, response_util.NotModified(r, etag, lastModified))
+}
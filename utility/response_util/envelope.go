@@ -0,0 +1,116 @@
+package response_util
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+const (
+	// JSONContentType is the content type written by every envelope helper
+	JSONContentType = "application/json; charset=utf-8"
+	// RequestIDHeader is the header used to read the request id of ResponseMeta
+	RequestIDHeader = "X-Request-ID"
+)
+
+// APIVersion will be included in every ResponseMeta, set it once on application start
+var APIVersion = ""
+
+// ResponseMeta holds request metadata included in every envelope
+type ResponseMeta struct {
+	RequestID  string    `json:"request_id,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	APIVersion string    `json:"api_version,omitempty"`
+}
+
+// Response is the envelope for single resource and mutation
+type Response struct {
+	Data interface{}  `json:"data"`
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ErrorResponse is the envelope for error
+type ErrorResponse struct {
+	Error errors.CustomError `json:"error"`
+	Meta  ResponseMeta       `json:"meta"`
+}
+
+// NewResponseMeta will create ResponseMeta of the request, request can be nil
+func NewResponseMeta(r *http.Request) ResponseMeta {
+	meta := ResponseMeta{
+		Timestamp:  time.Now().UTC(),
+		APIVersion: APIVersion,
+	}
+
+	if r != nil {
+		meta.RequestID = r.Header.Get(RequestIDHeader)
+	}
+
+	return meta
+}
+
+// NewResponse will create success envelope of data
+func NewResponse(r *http.Request, data interface{}) Response {
+	return Response{
+		Data: data,
+		Meta: NewResponseMeta(r),
+	}
+}
+
+// NewErrorResponse will create error envelope of err
+// error that is not errors.CustomError will be replaced by errors.ErrTetapTenangTetapSemangat
+// the error message is localized by the request Accept-Language header and the stack trace is stripped
+func NewErrorResponse(r *http.Request, err error) ErrorResponse {
+	var customError errors.CustomError
+	if !errors.As(err, &customError) {
+		customError = errors.ErrTetapTenangTetapSemangat
+	}
+	if r != nil {
+		customError = customError.Localize(r.Header.Get("Accept-Language"))
+	}
+	customError.HTTPCode = errorStatusCode(customError.HTTPCode)
+
+	return ErrorResponse{
+		Error: customError.WithoutStack(),
+		Meta:  NewResponseMeta(r),
+	}
+}
+
+// WriteJSON will write body as json with the given status code
+// invalid status code, e.g. zero of CustomError without HTTPCode, is written as 500
+func WriteJSON(w http.ResponseWriter, statusCode int, body interface{}) error {
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(errorStatusCode(statusCode))
+	return json.NewEncoder(w).Encode(body)
+}
+
+// WriteOK will write success envelope with 200 status code
+func WriteOK(w http.ResponseWriter, r *http.Request, data interface{}) error {
+	return WriteJSON(w, http.StatusOK, NewResponse(r, data))
+}
+
+// WriteCreated will write success envelope with 201 status code
+func WriteCreated(w http.ResponseWriter, r *http.Request, data interface{}) error {
+	return WriteJSON(w, http.StatusCreated, NewResponse(r, data))
+}
+
+// WriteAccepted will write success envelope with 202 status code
+func WriteAccepted(w http.ResponseWriter, r *http.Request, data interface{}) error {
+	return WriteJSON(w, http.StatusAccepted, NewResponse(r, data))
+}
+
+// WriteError will write error envelope with the error http code
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	response := NewErrorResponse(r, err)
+	return WriteJSON(w, response.Error.HTTPCode, response)
+}
+
+// errorStatusCode will return 500 for status code that can not be written by http.ResponseWriter
+func errorStatusCode(statusCode int) int {
+	if statusCode < 100 || statusCode > 599 {
+		return http.StatusInternalServerError
+	}
+	return statusCode
+}
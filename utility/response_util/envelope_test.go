@@ -0,0 +1,112 @@
+package response_util_test
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+func TestWriteCreated(t *testing.T) {
+	response_util.APIVersion = "v1"
+	defer func() { response_util.APIVersion = "" }()
+
+	r := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	r.Header.Set(response_util.RequestIDHeader, "request-id")
+	w := httptest.NewRecorder()
+
+	err := response_util.WriteCreated(w, r, map[string]interface{}{"id": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, response_util.JSONContentType, w.Header().Get("Content-Type"))
+
+	var body response_util.Response
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"id": float64(1)}, body.Data)
+	assert.Equal(t, "request-id", body.Meta.RequestID)
+	assert.Equal(t, "v1", body.Meta.APIVersion)
+	assert.False(t, body.Meta.Timestamp.IsZero())
+}
+
+func TestWriteError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+
+	t.Run("with custom error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := response_util.WriteError(w, r, errors.ErrNotFound)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var body response_util.ErrorResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, errors.ErrNotFound.Message, body.Error.Message)
+	})
+
+	t.Run("with unknown error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := response_util.WriteError(w, r, goerrors.New("connection refused"))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("without http code", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := response_util.WriteError(w, r, errors.CustomError{Code: "ORDER_LOCKED", Message: "Order is locked"})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		var body response_util.ErrorResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusInternalServerError, body.Error.HTTPCode)
+	})
+
+	t.Run("with stack trace", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := response_util.WriteError(w, r, errors.ErrTetapTenangTetapSemangat.WithStack())
+		assert.Nil(t, err)
+		assert.NotContains(t, w.Body.String(), "stack")
+	})
+
+	t.Run("with accept language", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+		r.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+
+		err := response_util.WriteError(w, r, errors.ErrNotFound)
+		assert.Nil(t, err)
+
+		var body response_util.ErrorResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "Data tidak ditemukan", body.Error.Message)
+	})
+}
+
+func TestIndexResponseExtraMeta(t *testing.T) {
+	body, err := json.Marshal(response_util.IndexResponse{
+		Data: []int{},
+		Meta: response_util.PaginationMeta{
+			Limit: 20,
+			Total: 2,
+			Extra: map[string]interface{}{
+				"total":      100,
+				"aggregates": map[string]interface{}{"total_amount": 3000},
+			},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t,
+		`{"data":[],"meta":{"limit":20,"offset":0,"total":2,"aggregates":{"total_amount":3000}}}`,
+		string(body),
+	)
+}
@@ -0,0 +1,351 @@
+package response_util
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/PhantomX7/go-core/utility/request_util"
+)
+
+// JSONAPIMediaType is the content type of JSON:API document
+const JSONAPIMediaType = "application/vnd.api+json"
+
+var jsonapiSchemaCache = &sync.Map{}
+
+// JSONAPITyper can be implemented by model to override the resource type
+// table name of the model will be used by default
+type JSONAPITyper interface {
+	JSONAPIType() string
+}
+
+// JSONAPIDocument is a top level JSON:API document
+// Data is *JSONAPIResource for single resource and []JSONAPIResource for collection
+type JSONAPIDocument struct {
+	Data     interface{}            `json:"data"`
+	Included []JSONAPIResource      `json:"included,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	Links    map[string]string      `json:"links,omitempty"`
+}
+
+// JSONAPIResource is a JSON:API resource object
+type JSONAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]interface{}         `json:"attributes,omitempty"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+}
+
+// JSONAPIRelationship is a JSON:API relationship object
+// Data is *JSONAPIResourceIdentifier for to-one and []JSONAPIResourceIdentifier for to-many relation
+type JSONAPIRelationship struct {
+	Data interface{} `json:"data"`
+}
+
+// JSONAPIResourceIdentifier is a JSON:API resource identifier object
+type JSONAPIResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// NewJSONAPIDocument will build JSON:API document of a single model or slice of model
+// attributes are named by json tag, relations that are preloaded will be added to included
+// relation that is nil (not preloaded) will be omitted from relationships
+func NewJSONAPIDocument(data interface{}) (JSONAPIDocument, error) {
+	builder := jsonapiBuilder{
+		included: make([]JSONAPIResource, 0),
+		visited:  make(map[string]bool),
+		primary:  make(map[string]bool),
+	}
+
+	document := JSONAPIDocument{}
+	value := reflect.Indirect(reflect.ValueOf(data))
+
+	if value.Kind() == reflect.Slice {
+		resources := make([]JSONAPIResource, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			resource, err := builder.resource(value.Index(i))
+			if err != nil {
+				return document, err
+			}
+			if resource != nil {
+				resources = append(resources, *resource)
+			}
+		}
+		document.Data = resources
+	} else {
+		resource, err := builder.resource(value)
+		if err != nil {
+			return document, err
+		}
+		document.Data = resource
+	}
+
+	// primary resource must not be repeated inside included
+	for _, resource := range builder.included {
+		if !builder.primary[jsonapiKey(resource.Type, resource.ID)] {
+			document.Included = append(document.Included, resource)
+		}
+	}
+
+	return document, nil
+}
+
+// NewJSONAPIIndexDocument will build JSON:API document of slice of model with pagination meta and links
+// links are built from requestURL by replacing limit and offset query
+func NewJSONAPIIndexDocument(data interface{}, config request_util.PaginationConfig, total int64, requestURL *url.URL) (JSONAPIDocument, error) {
+	document, err := NewJSONAPIDocument(data)
+	if err != nil {
+		return document, err
+	}
+
+	document.Meta = map[string]interface{}{
+		"limit":  config.Limit(),
+		"offset": config.Offset(),
+		"total":  total,
+	}
+
+	if requestURL != nil {
+		document.Links = paginationLinks(requestURL, config.Limit(), config.Offset(), total)
+	}
+
+	return document, nil
+}
+
+type jsonapiBuilder struct {
+	included []JSONAPIResource
+	visited  map[string]bool
+	primary  map[string]bool
+}
+
+// resource build resource object of model value, nil model will return nil
+func (b *jsonapiBuilder) resource(value reflect.Value) (*JSONAPIResource, error) {
+	resource, err := b.build(value)
+	if err != nil || resource == nil {
+		return resource, err
+	}
+
+	b.primary[jsonapiKey(resource.Type, resource.ID)] = true
+
+	return resource, nil
+}
+
+func (b *jsonapiBuilder) build(value reflect.Value) (*JSONAPIResource, error) {
+	value = addressable(value)
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	modelSchema, err := schema.Parse(value.Addr().Interface(), jsonapiSchemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return nil, err
+	}
+	if modelSchema.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("jsonapi: model %s has no primary key", modelSchema.Name)
+	}
+
+	resource := JSONAPIResource{
+		Type:          jsonapiType(value, modelSchema),
+		ID:            jsonapiID(value, modelSchema),
+		Attributes:    make(map[string]interface{}),
+		Relationships: make(map[string]JSONAPIRelationship),
+	}
+
+	// gorm also register the reverse relation of other schema, only relation owned by this schema is used
+	relations := make([]*schema.Relationship, 0)
+	relationFields := make(map[string]bool)
+	for _, relation := range modelSchema.Relationships.Relations {
+		if relation.Schema == modelSchema {
+			relations = append(relations, relation)
+			relationFields[relation.Field.Name] = true
+		}
+	}
+
+	for _, field := range modelSchema.Fields {
+		if field == modelSchema.PrioritizedPrimaryField || field.DBName == "" || relationFields[field.Name] {
+			continue
+		}
+
+		name, ok := jsonapiName(field)
+		if !ok {
+			continue
+		}
+
+		fieldValue, _ := field.ValueOf(value)
+		resource.Attributes[name] = fieldValue
+	}
+
+	for _, relation := range relations {
+		name, ok := jsonapiName(relation.Field)
+		if !ok {
+			continue
+		}
+
+		relationship, err := b.relationship(relation.Field.ReflectValueOf(value))
+		if err != nil {
+			return nil, err
+		}
+		if relationship != nil {
+			resource.Relationships[name] = *relationship
+		}
+	}
+
+	return &resource, nil
+}
+
+// relationship build relationship object and add the related resources to included
+// will return nil if relation is not preloaded
+func (b *jsonapiBuilder) relationship(value reflect.Value) (*JSONAPIRelationship, error) {
+	if value.Kind() == reflect.Slice {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		identifiers := make([]JSONAPIResourceIdentifier, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			identifier, err := b.include(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if identifier != nil {
+				identifiers = append(identifiers, *identifier)
+			}
+		}
+		return &JSONAPIRelationship{Data: identifiers}, nil
+	}
+
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+	if value.Kind() == reflect.Struct && value.IsZero() {
+		return nil, nil
+	}
+
+	identifier, err := b.include(value)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONAPIRelationship{Data: identifier}, nil
+}
+
+// include add related resource to included once and return its identifier
+func (b *jsonapiBuilder) include(value reflect.Value) (*JSONAPIResourceIdentifier, error) {
+	value = addressable(value)
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	modelSchema, err := schema.Parse(value.Addr().Interface(), jsonapiSchemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return nil, err
+	}
+	if modelSchema.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("jsonapi: model %s has no primary key", modelSchema.Name)
+	}
+
+	identifier := JSONAPIResourceIdentifier{
+		Type: jsonapiType(value, modelSchema),
+		ID:   jsonapiID(value, modelSchema),
+	}
+
+	key := jsonapiKey(identifier.Type, identifier.ID)
+	if !b.visited[key] {
+		b.visited[key] = true
+
+		resource, err := b.build(value)
+		if err != nil {
+			return nil, err
+		}
+		b.included = append(b.included, *resource)
+	}
+
+	return &identifier, nil
+}
+
+// addressable dereference pointer and copy the value if it can not be addressed
+// so the model schema and JSONAPITyper can be resolved from its pointer
+func addressable(value reflect.Value) reflect.Value {
+	value = reflect.Indirect(value)
+	if !value.IsValid() || value.CanAddr() {
+		return value
+	}
+
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr.Elem()
+}
+
+func jsonapiType(value reflect.Value, modelSchema *schema.Schema) string {
+	if typer, ok := value.Addr().Interface().(JSONAPITyper); ok {
+		return typer.JSONAPIType()
+	}
+	return modelSchema.Table
+}
+
+func jsonapiID(value reflect.Value, modelSchema *schema.Schema) string {
+	id, _ := modelSchema.PrioritizedPrimaryField.ValueOf(value)
+	return fmt.Sprint(id)
+}
+
+// jsonapiName return the member name from json tag, field with json "-" tag will be skipped
+func jsonapiName(field *schema.Field) (string, bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "-" {
+		return "", false
+	}
+	if tag != "" {
+		return tag, true
+	}
+	if field.DBName != "" {
+		return field.DBName, true
+	}
+	return field.Name, true
+}
+
+func jsonapiKey(resourceType string, id string) string {
+	return resourceType + ":" + id
+}
+
+// paginationLinks build self, first, prev, next and last links given limit and offset
+func paginationLinks(requestURL *url.URL, limit int, offset int, total int64) map[string]string {
+	link := func(linkOffset int) string {
+		linkURL := *requestURL
+		query := linkURL.Query()
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(linkOffset))
+		linkURL.RawQuery = query.Encode()
+		return linkURL.String()
+	}
+
+	links := map[string]string{
+		"self":  link(offset),
+		"first": link(0),
+	}
+	if limit <= 0 {
+		return links
+	}
+
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links["prev"] = link(prev)
+	}
+	if int64(offset+limit) < total {
+		links["next"] = link(offset + limit)
+	}
+
+	last := 0
+	if total > 0 {
+		last = int((total - 1) / int64(limit) * int64(limit))
+	}
+	links["last"] = link(last)
+
+	return links
+}
@@ -0,0 +1,82 @@
+package response_util_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/request_util"
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+type Customer struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type Item struct {
+	ID      uint   `json:"id"`
+	OrderID uint   `json:"order_id"`
+	Name    string `json:"name"`
+}
+
+type Order struct {
+	ID         uint      `json:"id"`
+	Number     string    `json:"number"`
+	Secret     string    `json:"-"`
+	CustomerID uint      `json:"customer_id"`
+	Customer   *Customer `json:"customer"`
+	Items      []Item    `json:"items"`
+}
+
+func (Order) JSONAPIType() string {
+	return "order"
+}
+
+func TestNewJSONAPIDocument(t *testing.T) {
+	customer := &Customer{ID: 1, Name: "customer"}
+	orders := []Order{
+		{ID: 1, Number: "INV-1", Secret: "secret", CustomerID: 1, Customer: customer, Items: []Item{{ID: 1, OrderID: 1, Name: "item"}}},
+		{ID: 2, Number: "INV-2", CustomerID: 1, Customer: customer},
+	}
+
+	document, err := response_util.NewJSONAPIDocument(orders)
+	assert.Nil(t, err)
+
+	resources := document.Data.([]response_util.JSONAPIResource)
+	assert.Equal(t, 2, len(resources))
+	assert.Equal(t, "order", resources[0].Type)
+	assert.Equal(t, "1", resources[0].ID)
+	assert.Equal(t, "INV-1", resources[0].Attributes["number"])
+	assert.NotContains(t, resources[0].Attributes, "Secret")
+	assert.NotContains(t, resources[0].Attributes, "customer")
+	assert.Equal(t,
+		&response_util.JSONAPIResourceIdentifier{Type: "customers", ID: "1"},
+		resources[0].Relationships["customer"].Data,
+	)
+	assert.Equal(t,
+		[]response_util.JSONAPIResourceIdentifier{{Type: "items", ID: "1"}},
+		resources[0].Relationships["items"].Data,
+	)
+	assert.NotContains(t, resources[1].Relationships, "items") // items is not preloaded
+
+	assert.Equal(t, 2, len(document.Included)) // customer is included once
+}
+
+func TestNewJSONAPIIndexDocument(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.test/orders?status=paid&limit=1&offset=1")
+
+	document, err := response_util.NewJSONAPIIndexDocument(
+		[]Order{{ID: 2, Number: "INV-2"}},
+		request_util.NewPaginationConfig(1, 1, ""),
+		3,
+		requestURL,
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(3), document.Meta["total"])
+	assert.Equal(t, "https://api.test/orders?limit=1&offset=0&status=paid", document.Links["prev"])
+	assert.Equal(t, "https://api.test/orders?limit=1&offset=2&status=paid", document.Links["next"])
+	assert.Equal(t, "https://api.test/orders?limit=1&offset=2&status=paid", document.Links["last"])
+}
@@ -0,0 +1,168 @@
+package response_util
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/validators"
+)
+
+// ProblemContentType is the content type of RFC 7807 problem document
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBaseURI is prepended to the kebab case error code to build the problem type
+// e.g. https://api.example.com/problems/ with NOT_FOUND code become https://api.example.com/problems/not-found
+// problem type will be about:blank if it is empty
+var ProblemTypeBaseURI = ""
+
+// Problem is RFC 7807 problem details document
+// Extensions is merged into the document as extension members
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// InvalidParam is a single validation failure in invalid-params extension member
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem will convert err into problem document, request can be nil
+// error that is not errors.CustomError will be converted from errors.ErrTetapTenangTetapSemangat
+// validator.ValidationErrors will be converted into 422 with invalid-params extension member
+// the error message is localized by the request Accept-Language header
+func NewProblem(r *http.Request, err error) Problem {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return newValidationProblem(r, validationErrors)
+	}
+
+	var customError errors.CustomError
+	if !errors.As(err, &customError) {
+		customError = errors.ErrTetapTenangTetapSemangat
+	}
+	customError = customError.Localize(acceptLanguage(r))
+	customError.HTTPCode = errorStatusCode(customError.HTTPCode)
+
+	problem := Problem{
+		Type:       problemType(customError.Code),
+		Title:      http.StatusText(customError.HTTPCode),
+		Status:     customError.HTTPCode,
+		Extensions: make(map[string]interface{}),
+	}
+
+	// non string message such as field errors is kept as extension member
+	if message, ok := customError.Message.(string); ok {
+		problem.Detail = message
+	} else if customError.Message != nil {
+		problem.Detail = problem.Title
+		problem.Extensions["errors"] = customError.Message
+	}
+
+	if customError.Code != "" {
+		problem.Extensions["code"] = customError.Code
+	}
+	for key, value := range customError.Details() {
+		problem.Extensions[key] = value
+	}
+
+	setProblemRequest(&problem, r)
+	return problem
+}
+
+// WriteProblem will write err as problem document with its status code
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) error {
+	problem := NewProblem(r, err)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(errorStatusCode(problem.Status))
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// ProblemHandler will return http.Handler that write err as problem document
+func ProblemHandler(err error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = WriteProblem(w, r, err)
+	})
+}
+
+// MarshalJSON will marshal the problem with its extension members
+// extension member can not override the standard member
+func (p Problem) MarshalJSON() ([]byte, error) {
+	document := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		document[key] = value
+	}
+
+	document["type"] = p.Type
+	document["title"] = p.Title
+	document["status"] = p.Status
+	if p.Detail != "" {
+		document["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		document["instance"] = p.Instance
+	}
+
+	return json.Marshal(document)
+}
+
+func newValidationProblem(r *http.Request, validationErrors validator.ValidationErrors) Problem {
+	printer := errors.NewPrinter(acceptLanguage(r))
+	invalidParams := make([]InvalidParam, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		invalidParams = append(invalidParams, InvalidParam{
+			Name:   validators.FieldName(fieldError),
+			Reason: validators.LocalizedFieldMessage(fieldError, printer),
+		})
+	}
+
+	problem := Problem{
+		Type:   problemType(errors.ErrUnprocessableEntity.Code),
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Detail: "request validation failed",
+		Extensions: map[string]interface{}{
+			"code":           errors.ErrUnprocessableEntity.Code,
+			"invalid-params": invalidParams,
+		},
+	}
+
+	setProblemRequest(&problem, r)
+	return problem
+}
+
+func setProblemRequest(problem *Problem, r *http.Request) {
+	if r == nil {
+		return
+	}
+
+	problem.Instance = r.URL.RequestURI()
+	if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+		problem.Extensions["request_id"] = requestID
+	}
+}
+
+func acceptLanguage(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	return r.Header.Get("Accept-Language")
+}
+
+// problemType build problem type uri from the error code
+func problemType(code string) string {
+	if ProblemTypeBaseURI == "" || code == "" {
+		return "about:blank"
+	}
+	return ProblemTypeBaseURI + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
@@ -0,0 +1,74 @@
+package response_util_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/response_util"
+)
+
+func TestProblemHandler(t *testing.T) {
+	response_util.ProblemTypeBaseURI = "https://api.test/problems/"
+	defer func() { response_util.ProblemTypeBaseURI = "" }()
+
+	r := httptest.NewRequest(http.MethodGet, "/orders/12?include=items", nil)
+	r.Header.Set(response_util.RequestIDHeader, "request-id")
+	w := httptest.NewRecorder()
+
+	err := errors.Wrap(errors.ErrNotFound, nil, "order 12").
+		WithDetails(map[string]interface{}{"order_id": 12})
+	response_util.ProblemHandler(err).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, response_util.ProblemContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "https://api.test/problems/not-found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "Record not exist: order 12",
+		"instance": "/orders/12?include=items",
+		"code": "NOT_FOUND",
+		"order_id": 12,
+		"request_id": "request-id"
+	}`, w.Body.String())
+}
+
+func TestWriteProblemWithoutHTTPCode(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := response_util.WriteProblem(w, nil, errors.CustomError{Code: "ORDER_LOCKED", Message: "Order is locked"})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var problem map[string]interface{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, float64(http.StatusInternalServerError), problem["status"])
+	assert.Equal(t, "Internal Server Error", problem["title"])
+}
+
+func TestNewProblemFromValidationErrors(t *testing.T) {
+	type request struct {
+		Name string `validate:"required"`
+	}
+	err := validator.New().Struct(request{})
+
+	problem := response_util.NewProblem(nil, err)
+	body, _ := json.Marshal(problem)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "request validation failed",
+		"code": "UNPROCESSABLE_ENTITY",
+		"invalid-params": [{"name": "Name", "reason": "Name is required"}]
+	}`, string(body))
+}
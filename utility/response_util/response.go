@@ -1,12 +1,32 @@
 package response_util
 
+import "encoding/json"
+
+// PaginationMeta holds pagination data of IndexResponse
+// Extra is merged into the meta object, e.g. aggregate summary from request_util.RunAggregates
 type PaginationMeta struct {
-	Limit  int   `json:"limit"`
-	Offset int   `json:"offset"`
-	Total  int64 `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+	Total  int64                  `json:"total"`
+	Extra  map[string]interface{} `json:"-"`
 }
 
 type IndexResponse struct {
 	Data interface{}    `json:"data"`
 	Meta PaginationMeta `json:"meta"`
 }
+
+// MarshalJSON will marshal the pagination meta with its extra data
+// extra key can not override limit, offset and total
+func (m PaginationMeta) MarshalJSON() ([]byte, error) {
+	meta := make(map[string]interface{}, len(m.Extra)+3)
+	for key, value := range m.Extra {
+		meta[key] = value
+	}
+
+	meta["limit"] = m.Limit
+	meta["offset"] = m.Offset
+	meta["total"] = m.Total
+
+	return json.Marshal(meta)
+}
@@ -0,0 +1,203 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+)
+
+// ErrValidationFailed is returned by ValidationError with field messages
+var ErrValidationFailed = errors.MustRegister(
+	errors.ErrUnprocessableEntity.WithCode("VALIDATION_FAILED"),
+	"The request body is not valid, message is the map of field to its error",
+)
+
+// messageTemplates hold the english and indonesian message of each validation tag
+// the first verb is the field name and the second is the tag param
+var messageTemplates = map[string][2]string{
+	"required":         {"%s is required", "%s wajib diisi"},
+	"email":            {"%s must be a valid email address", "%s harus berupa alamat email yang valid"},
+	"url":              {"%s must be a valid url", "%s harus berupa url yang valid"},
+	"uuid":             {"%s must be a valid uuid", "%s harus berupa uuid yang valid"},
+	"numeric":          {"%s must be numeric", "%s harus berupa angka"},
+	"number":           {"%s must be a number", "%s harus berupa angka"},
+	"alpha":            {"%s must contain only letters", "%s hanya boleh berisi huruf"},
+	"alphanum":         {"%s must contain only letters and numbers", "%s hanya boleh berisi huruf dan angka"},
+	"oneof":            {"%s must be one of [%s]", "%s harus salah satu dari [%s]"},
+	"eq":               {"%s must be equal to %s", "%s harus sama dengan %s"},
+	"ne":               {"%s must not be equal to %s", "%s tidak boleh sama dengan %s"},
+	"eqfield":          {"%s must be equal to %s", "%s harus sama dengan %s"},
+	"nefield":          {"%s must not be equal to %s", "%s tidak boleh sama dengan %s"},
+	"datetime":         {"%s must follow the format %s", "%s harus sesuai format %s"},
+	"unique":           {"%s has already been taken", "%s sudah digunakan"},
+	"exist":            {"%s does not exist", "%s tidak ditemukan"},
+	"required_with":    {"%s is required when %s is present", "%s wajib diisi jika %s diisi"},
+	"required_without": {"%s is required when %s is not present", "%s wajib diisi jika %s tidak diisi"},
+	"invalid":          {"%s is invalid", "%s tidak valid"},
+}
+
+// sizeTemplates hold the english and indonesian message of size tag, keyed by tag and then by kind of the field
+var sizeTemplates = map[string]map[string][2]string{
+	"min": {
+		"string": {"%s must be at least %s characters", "%s minimal %s karakter"},
+		"number": {"%s must be %s or greater", "%s minimal %s"},
+		"items":  {"%s must contain at least %s items", "%s minimal berisi %s item"},
+	},
+	"max": {
+		"string": {"%s must be at most %s characters", "%s maksimal %s karakter"},
+		"number": {"%s must be %s or less", "%s maksimal %s"},
+		"items":  {"%s must contain at most %s items", "%s maksimal berisi %s item"},
+	},
+	"len": {
+		"string": {"%s must be %s characters long", "%s harus %s karakter"},
+		"number": {"%s must be equal to %s", "%s harus sama dengan %s"},
+		"items":  {"%s must contain %s items", "%s harus berisi %s item"},
+	},
+	"gte": {
+		"string": {"%s must be at least %s characters", "%s minimal %s karakter"},
+		"number": {"%s must be %s or greater", "%s minimal %s"},
+		"items":  {"%s must contain at least %s items", "%s minimal berisi %s item"},
+	},
+	"lte": {
+		"string": {"%s must be at most %s characters", "%s maksimal %s karakter"},
+		"number": {"%s must be %s or less", "%s maksimal %s"},
+		"items":  {"%s must contain at most %s items", "%s maksimal berisi %s item"},
+	},
+	"gt": {
+		"string": {"%s must be longer than %s characters", "%s harus lebih dari %s karakter"},
+		"number": {"%s must be greater than %s", "%s harus lebih besar dari %s"},
+		"items":  {"%s must contain more than %s items", "%s harus berisi lebih dari %s item"},
+	},
+	"lt": {
+		"string": {"%s must be shorter than %s characters", "%s harus kurang dari %s karakter"},
+		"number": {"%s must be less than %s", "%s harus lebih kecil dari %s"},
+		"items":  {"%s must contain less than %s items", "%s harus berisi kurang dari %s item"},
+	},
+}
+
+func init() {
+	for tag, templates := range messageTemplates {
+		setTranslation(messageKey(tag, ""), templates)
+	}
+	for tag, kinds := range sizeTemplates {
+		for kind, templates := range kinds {
+			setTranslation(messageKey(tag, kind), templates)
+		}
+	}
+
+	errors.RegisterMessageFunc(ErrValidationFailed.Code, func(c errors.CustomError, printer *message.Printer) interface{} {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(c.Unwrap(), &validationErrors) {
+			return c.Message
+		}
+		return fieldMessages(validationErrors, printer)
+	})
+}
+
+// RegisterJSONTagName will make the validator report field by its json tag name
+// so ValidationError and FieldName respect the json tag
+func RegisterJSONTagName(v *validator.Validate) {
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// ValidationError will convert validator.ValidationErrors into errors.ErrUnprocessableEntity
+// with message of field name to human message, e.g. {"address.street": "address.street is required"}
+// the message will be translated by errors.CustomError Localize
+// error that is not validator.ValidationErrors will be returned as is
+func ValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	return ErrValidationFailed.
+		WithMessage(fieldMessages(validationErrors, englishPrinter())).
+		WithCause(err)
+}
+
+// FieldName will return the field namespace without the root struct name
+// e.g. CreateOrderRequest.address.street become address.street
+func FieldName(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldError.Field()
+}
+
+// FieldMessage will return the english human message of the field error
+func FieldMessage(fieldError validator.FieldError) string {
+	return LocalizedFieldMessage(fieldError, englishPrinter())
+}
+
+// LocalizedFieldMessage will return the human message of the field error using the printer
+// printer can be created with errors.NewPrinter from Accept-Language header value
+func LocalizedFieldMessage(fieldError validator.FieldError, printer *message.Printer) string {
+	name := FieldName(fieldError)
+
+	var key string
+	var template string
+	if templates, ok := sizeTemplates[fieldError.Tag()]; ok {
+		kind := sizeKind(fieldError.Kind())
+		key, template = messageKey(fieldError.Tag(), kind), templates[kind][0]
+	} else if templates, ok := messageTemplates[fieldError.Tag()]; ok {
+		key, template = messageKey(fieldError.Tag(), ""), templates[0]
+	} else {
+		key, template = messageKey("invalid", ""), messageTemplates["invalid"][0]
+	}
+
+	if strings.Count(template, "%s") == 2 {
+		return printer.Sprintf(key, name, fieldError.Param())
+	}
+	return printer.Sprintf(key, name)
+}
+
+func fieldMessages(validationErrors validator.ValidationErrors, printer *message.Printer) map[string]string {
+	messages := make(map[string]string, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		name := FieldName(fieldError)
+		if _, ok := messages[name]; !ok {
+			messages[name] = LocalizedFieldMessage(fieldError, printer)
+		}
+	}
+	return messages
+}
+
+func englishPrinter() *message.Printer {
+	return errors.NewPrinter(language.English.String())
+}
+
+func messageKey(tag string, kind string) string {
+	if kind == "" {
+		return "validator." + tag
+	}
+	return "validator." + tag + "." + kind
+}
+
+func setTranslation(key string, templates [2]string) {
+	_ = errors.SetTranslation(language.English, key, templates[0])
+	_ = errors.SetTranslation(language.Indonesian, key, templates[1])
+}
+
+// sizeKind group the field kind used by size tag
+func sizeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "number"
+	}
+}
@@ -0,0 +1,73 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PhantomX7/go-core/utility/errors"
+	"github.com/PhantomX7/go-core/utility/validators"
+)
+
+type address struct {
+	Street string `json:"street" validate:"required"`
+}
+
+type createUserRequest struct {
+	Email    string   `json:"email" validate:"required,email,unique=users.email"`
+	Name     string   `json:"name" validate:"min=3"`
+	Age      int      `json:"age" validate:"gte=17"`
+	Roles    []string `json:"roles" validate:"max=1"`
+	Address  address  `json:"address"`
+	Password string   `json:"-" validate:"required"`
+}
+
+func TestValidationError(t *testing.T) {
+	v := validator.New()
+	validators.RegisterJSONTagName(v)
+	_ = v.RegisterValidation("unique", func(fl validator.FieldLevel) bool {
+		return false
+	})
+
+	err := validators.ValidationError(v.Struct(createUserRequest{
+		Email: "test@mail.com",
+		Name:  "ab",
+		Age:   10,
+		Roles: []string{"admin", "user"},
+	}))
+
+	customError, ok := err.(errors.CustomError)
+	assert.True(t, ok)
+	assert.True(t, errors.Is(err, errors.ErrUnprocessableEntity))
+	assert.Equal(t, 422, customError.HTTPCode)
+	assert.Equal(t, map[string]string{
+		"email":          "email has already been taken",
+		"name":           "name must be at least 3 characters",
+		"age":            "age must be 17 or greater",
+		"roles":          "roles must contain at most 1 items",
+		"address.street": "address.street is required",
+		"Password":       "Password is required",
+	}, customError.Message)
+}
+
+func TestValidationErrorWithOtherError(t *testing.T) {
+	assert.Equal(t, errors.ErrNotFound, validators.ValidationError(errors.ErrNotFound))
+	assert.Nil(t, validators.ValidationError(nil))
+}
+
+func TestValidationErrorLocalize(t *testing.T) {
+	v := validator.New()
+	validators.RegisterJSONTagName(v)
+
+	err := validators.ValidationError(v.Struct(address{}))
+
+	customError, ok := err.(errors.CustomError)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"street": "street wajib diisi",
+	}, customError.Localize("id").Message)
+	assert.Equal(t, map[string]string{
+		"street": "street is required",
+	}, customError.Localize("en").Message)
+}